@@ -0,0 +1,56 @@
+package bitboard
+
+import (
+	"chess/board/bitboard/magic"
+	"chess/pieces"
+)
+
+var (
+	knightAttacks [64]Bitboard
+	kingAttacks   [64]Bitboard
+	// pawnAttacks is indexed by the color of the attacking pawn
+	pawnAttacks [2][64]Bitboard
+)
+
+func init() {
+	knightSteps := [][2]int{{-1, 2}, {1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}}
+	kingSteps := [][2]int{{0, 1}, {1, 1}, {1, 0}, {-1, 1}, {0, -1}, {-1, -1}, {-1, 0}, {1, -1}}
+
+	for s := Square(0); s < 64; s++ {
+		knightAttacks[s] = stepTargets(s, knightSteps)
+		kingAttacks[s] = stepTargets(s, kingSteps)
+		pawnAttacks[pieces.WHITE][s] = stepTargets(s, [][2]int{{1, 1}, {1, -1}})
+		pawnAttacks[pieces.BLACK][s] = stepTargets(s, [][2]int{{-1, 1}, {-1, -1}})
+	}
+}
+
+func onBoard(row, col int) bool {
+	return row >= 0 && row < boardSize && col >= 0 && col < boardSize
+}
+
+// stepTargets returns the squares a single step away from a square
+func stepTargets(s Square, steps [][2]int) Bitboard {
+	var targets Bitboard
+	for _, step := range steps {
+		row, col := s.row()+step[0], s.col()+step[1]
+		if onBoard(row, col) {
+			targets |= squareBit(Square(row*boardSize + col))
+		}
+	}
+	return targets
+}
+
+// RookAttacks returns the squares a rook on a square attacks given the occupied squares
+func RookAttacks(s Square, occupied Bitboard) Bitboard {
+	return Bitboard(magic.RookAttacks(int(s), uint64(occupied)))
+}
+
+// BishopAttacks returns the squares a bishop on a square attacks given the occupied squares
+func BishopAttacks(s Square, occupied Bitboard) Bitboard {
+	return Bitboard(magic.BishopAttacks(int(s), uint64(occupied)))
+}
+
+// QueenAttacks returns the squares a queen on a square attacks given the occupied squares
+func QueenAttacks(s Square, occupied Bitboard) Bitboard {
+	return Bitboard(magic.QueenAttacks(int(s), uint64(occupied)))
+}
@@ -0,0 +1,78 @@
+package bitboard
+
+import (
+	"chess/board/location"
+	"math/bits"
+)
+
+// Bitboard is a set of squares, with bit n set when square n is in the set
+type Bitboard uint64
+
+// Square is the index of a location on an 8x8 board, counting along each rank from a1 = 0 to h8 = 63
+type Square int8
+
+// NoSquare marks the absence of a square, such as when there is no en passant target
+const NoSquare Square = -1
+
+const boardSize = 8
+
+// SquareOf returns the square at a given location
+func SquareOf(loc location.Location) Square {
+	return Square(loc.GetRow()*boardSize + loc.GetCol())
+}
+
+// Location returns the location of the square
+func (s Square) Location() location.Location {
+	return location.Location{Row: int(s) / boardSize, Col: int(s) % boardSize}
+}
+
+func (s Square) String() string {
+	if s == NoSquare {
+		return "-"
+	}
+	return s.Location().String()
+}
+
+func (s Square) row() int {
+	return int(s) / boardSize
+}
+
+func (s Square) col() int {
+	return int(s) % boardSize
+}
+
+// squareBit returns the bitboard containing only a given square
+func squareBit(s Square) Bitboard {
+	return 1 << uint(s)
+}
+
+// Has returns whether the bitboard contains a given square
+func (b Bitboard) Has(s Square) bool {
+	return b&squareBit(s) != 0
+}
+
+// Count returns the number of squares in the bitboard
+func (b Bitboard) Count() int {
+	return bits.OnesCount64(uint64(b))
+}
+
+// Squares returns the squares in the bitboard in ascending order
+func (b Bitboard) Squares() []Square {
+	squares := make([]Square, 0, b.Count())
+	for b != 0 {
+		squares = append(squares, b.pop())
+	}
+	return squares
+}
+
+// first returns the lowest square in a non-empty bitboard
+func (b Bitboard) first() Square {
+	return Square(bits.TrailingZeros64(uint64(b)))
+}
+
+// pop removes the lowest square from a non-empty bitboard and returns it
+func (b *Bitboard) pop() Square {
+	s := b.first()
+	*b &= *b - 1
+	return s
+}
@@ -0,0 +1,265 @@
+package bitboard_test
+
+import (
+	"chess/board/bitboard"
+	"chess/board/location"
+	"chess/game"
+	"chess/pieces"
+	"sort"
+	"testing"
+)
+
+var positions = []string{
+	game.StartingFEN,
+	// Kiwipete, which exercises castling, en passant and promotion
+	"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+	"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+	"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+	"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8",
+	"4k3/8/8/2KpP3/8/8/8/8 w - d6 0 1",
+}
+
+func TestGenerateMovesAndHashMatchPieces(t *testing.T) {
+	for _, fen := range positions {
+		g, err := game.NewGameFromFEN(fen)
+		if err != nil {
+			t.Fatalf("%s: %v", fen, err)
+		}
+		compareMoves(g, 2, t)
+	}
+
+	// an en passant target with no pawn in front of it, as in "4k3/8/8/8/3p4/8/8/4K3 b - e3 0 1", cannot be captured.
+	// FEN parsing rejects such a target, so the pieces are passed in directly
+	blackPawn := pieces.NewPawn(location.Location{Row: 4, Col: 3}, pieces.BLACK)
+	blackPawn.Move(location.Location{Row: 3, Col: 3})
+	pcs := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE),
+		pieces.NewKing(location.Location{Row: 7, Col: 4}, pieces.BLACK),
+		blackPawn,
+	}
+	enPassant := &location.Location{Row: 2, Col: 4}
+	expected := moveStrings(pieces.GenerateMoves(pieces.BLACK, pcs, enPassant))
+	actual := moveStrings(bitboard.GenerateMoves(pieces.BLACK, pcs, enPassant))
+	if len(expected) != len(actual) {
+		t.Fatalf("expected moves %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Fatalf("expected moves %v, got %v", expected, actual)
+		}
+	}
+}
+
+// compareMoves checks that the bitboard moves match the piece moves in a game and every position reachable from it
+// within a given number of moves
+func compareMoves(g *game.Game, depth int, t *testing.T) {
+	var enPassant *location.Location
+	if target, ok := g.EnPassantTarget(); ok {
+		enPassant = &target
+	}
+	expected := moveStrings(pieces.GenerateMoves(g.Turn(), g.Pieces(), enPassant))
+	actual := moveStrings(bitboard.GenerateMoves(g.Turn(), g.Pieces(), enPassant))
+	if len(expected) != len(actual) {
+		t.Fatalf("%s: expected moves %v, got %v", g.FEN(), expected, actual)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Fatalf("%s: expected moves %v, got %v", g.FEN(), expected, actual)
+		}
+	}
+
+	// the hash kept up to date through every move must match the hash of the position built from scratch
+	if hash := bitboard.FromPieces(g.Pieces(), g.Turn(), enPassant).Hash(); g.Hash() != hash {
+		t.Fatalf("%s: expected hash %x, got %x", g.FEN(), hash, g.Hash())
+	}
+
+	if depth == 1 {
+		return
+	}
+	for _, m := range g.LegalMoves() {
+		if err := g.MakeMove(m); err != nil {
+			t.Fatalf("%s: %v", g.FEN(), err)
+		}
+		compareMoves(g, depth-1, t)
+		if err := g.Undo(); err != nil {
+			t.Fatalf("%s: %v", g.FEN(), err)
+		}
+	}
+}
+
+// moveStrings returns the sorted moves along with their captured pieces and flags
+func moveStrings(moves []pieces.Move) []string {
+	var strs []string
+	for _, m := range moves {
+		s := m.String()
+		if m.Captured != nil {
+			s += "x" + m.Captured.Location().String()
+		}
+		strs = append(strs, s+string(rune('0'+m.Flags)))
+	}
+	sort.Strings(strs)
+	return strs
+}
+
+// perftResults are the published node counts for the standard perft test positions, indexed by depth - 1
+var perftResults = []struct {
+	fen   string
+	nodes []uint64
+}{
+	{game.StartingFEN, []uint64{20, 400, 8902, 197281, 4865609}},
+	// Kiwipete
+	{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", []uint64{48, 2039, 97862, 4085603}},
+	{"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", []uint64{14, 191, 2812, 43238, 674624}},
+	{"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", []uint64{6, 264, 9467, 422333}},
+	// the same position with the colors reversed
+	{"r2q1rk1/pP1p2pp/Q4n2/bbp1p3/Np6/1B3NBn/pPPP1PPP/R3K2R b KQ - 0 1", []uint64{6, 264, 9467, 422333}},
+	{"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", []uint64{44, 1486, 62379, 2103487}},
+	{"r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", []uint64{46, 2079, 89890, 3894594}},
+}
+
+func TestPerft(t *testing.T) {
+	for _, test := range perftResults {
+		pos := bitboardPosition(test.fen, t)
+		before := *pos
+
+		for depth, expected := range test.nodes {
+			// the deepest counts take a few seconds
+			if testing.Short() && expected > 100000 {
+				break
+			}
+			if nodes := pos.Perft(depth + 1); nodes != expected {
+				t.Errorf("%s: depth %d: expected %d positions, got %d", test.fen, depth+1, expected, nodes)
+			}
+		}
+		if *pos != before {
+			t.Errorf("%s: expected position to be restored after unmaking every move", test.fen)
+		}
+	}
+}
+
+func TestDivide(t *testing.T) {
+	pos := bitboardPosition(perftResults[1].fen, t)
+	results := pos.Divide(2)
+	if len(results) != int(perftResults[1].nodes[0]) {
+		t.Fatalf("expected %d moves, got %d", perftResults[1].nodes[0], len(results))
+	}
+
+	var total uint64
+	for i, result := range results {
+		if i > 0 && results[i-1].Move.String() >= result.Move.String() {
+			t.Errorf("expected moves in order, got %v before %v", results[i-1].Move, result.Move)
+		}
+		total += result.Nodes
+	}
+	if total != perftResults[1].nodes[1] {
+		t.Errorf("expected counts adding up to %d, got %d", perftResults[1].nodes[1], total)
+	}
+}
+
+func bitboardPosition(fen string, t *testing.T) *bitboard.Position {
+	g, err := game.NewGameFromFEN(fen)
+	if err != nil {
+		t.Fatalf("%s: %v", fen, err)
+	}
+	var enPassant *location.Location
+	if target, ok := g.EnPassantTarget(); ok {
+		enPassant = &target
+	}
+	return bitboard.FromPieces(g.Pieces(), g.Turn(), enPassant)
+}
+
+func TestPiecesRoundTrip(t *testing.T) {
+	// kings and rooks missing some of their castling rights
+	fens := append([]string{"r3k2r/8/8/8/8/8/8/R3K2R w Kq - 0 1", "r3k2r/8/8/8/8/8/8/R3K2R b - - 0 1"}, positions...)
+	for _, fen := range fens {
+		pos := bitboardPosition(fen, t)
+		var enPassant *location.Location
+		if target := pos.EnPassantTarget(); target != bitboard.NoSquare {
+			loc := target.Location()
+			enPassant = &loc
+		}
+		pcs := pos.Pieces()
+		if back := bitboard.FromPieces(pcs, pos.Turn(), enPassant); *back != *pos {
+			t.Errorf("%s: expected the pieces to give back the same position", fen)
+		}
+		if len(pcs) != pos.Occupied().Count() {
+			t.Errorf("%s: expected %d pieces, got %d", fen, pos.Occupied().Count(), len(pcs))
+		}
+	}
+}
+
+func TestLegalMovesAndAttacks(t *testing.T) {
+	pcs := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE),
+		pieces.NewRook(location.Location{Row: 1, Col: 4}, pieces.WHITE),
+		pieces.NewRook(location.Location{Row: 7, Col: 4}, pieces.BLACK),
+		pieces.NewKnight(location.Location{Row: 2, Col: 2}, pieces.BLACK),
+	}
+
+	// the white rook is pinned to the e-file
+	rook := pcs[1]
+	expected := pieces.LegalMoves(rook, pcs, nil)
+	actual := bitboard.LegalMoves(rook, pcs, nil)
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if !expected[i].Equals(actual[i]) {
+			t.Errorf("expected %v, got %v", expected, actual)
+		}
+	}
+
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			loc := location.Location{Row: row, Col: col}
+			if pieces.IsAttacked(loc, pieces.BLACK, pcs) != bitboard.IsAttacked(loc, pieces.BLACK, pcs) {
+				t.Errorf("%v: attack maps differ", loc)
+			}
+		}
+	}
+}
+
+func TestSlidingAttacks(t *testing.T) {
+	// occupancies built from a fixed sequence so that failures can be reproduced
+	occupancies := []bitboard.Bitboard{0, ^bitboard.Bitboard(0)}
+	x := uint64(88172645463325252)
+	for i := 0; i < 200; i++ {
+		x ^= x << 13
+		x ^= x >> 7
+		x ^= x << 17
+		occupancies = append(occupancies, bitboard.Bitboard(x&(x>>5)))
+	}
+
+	rookSteps := [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+	bishopSteps := [][2]int{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+	for s := bitboard.Square(0); s < 64; s++ {
+		for _, occupied := range occupancies {
+			if expected, actual := walkRays(s, occupied, rookSteps), bitboard.RookAttacks(s, occupied); expected != actual {
+				t.Fatalf("rook on %v with %x: expected %x, got %x", s, occupied, expected, actual)
+			}
+			if expected, actual := walkRays(s, occupied, bishopSteps), bitboard.BishopAttacks(s, occupied); expected != actual {
+				t.Fatalf("bishop on %v with %x: expected %x, got %x", s, occupied, expected, actual)
+			}
+		}
+	}
+}
+
+// walkRays returns the squares attacked along rays from a square by stepping one square at a time
+func walkRays(s bitboard.Square, occupied bitboard.Bitboard, steps [][2]int) bitboard.Bitboard {
+	var attacks bitboard.Bitboard
+	for _, step := range steps {
+		loc := s.Location()
+		for {
+			loc = location.Location{Row: loc.GetRow() + step[0], Col: loc.GetCol() + step[1]}
+			if !loc.IsValid(8) {
+				break
+			}
+			target := bitboard.SquareOf(loc)
+			attacks |= 1 << uint(target)
+			if occupied.Has(target) {
+				break
+			}
+		}
+	}
+	return attacks
+}
@@ -0,0 +1,119 @@
+// Package magic looks up the squares attacked by rooks and bishops in precomputed tables. Squares are counted along
+// each rank from a1 = 0 to h8 = 63, and a set of squares is a uint64 with bit n set when square n is in the set. The
+// package depends on nothing else in the module, so that both the piece rules and the bitboard backend can share
+// the tables
+package magic
+
+import "math/bits"
+
+//go:generate go test -run TestMagicNumbers -update
+
+const boardSize = 8
+
+// the row and column steps rooks and bishops move along
+var (
+	rookSteps   = [][2]int{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}
+	bishopSteps = [][2]int{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+)
+
+// table maps the occupied squares relevant to a slider on a square to the squares it attacks. The relevant occupancy
+// is multiplied by the magic number and shifted so that every occupancy with different attacks lands on a different
+// index
+type table struct {
+	mask    uint64
+	number  uint64
+	shift   uint
+	attacks []uint64
+}
+
+var (
+	rookTables   [64]table
+	bishopTables [64]table
+)
+
+func init() {
+	for s := 0; s < 64; s++ {
+		rookTables[s] = newTable(s, rookSteps, rookNumbers[s])
+		bishopTables[s] = newTable(s, bishopSteps, bishopNumbers[s])
+	}
+}
+
+// RookAttacks returns the squares a rook on a square attacks given the occupied squares
+func RookAttacks(s int, occupied uint64) uint64 {
+	return rookTables[s].lookup(occupied)
+}
+
+// BishopAttacks returns the squares a bishop on a square attacks given the occupied squares
+func BishopAttacks(s int, occupied uint64) uint64 {
+	return bishopTables[s].lookup(occupied)
+}
+
+// QueenAttacks returns the squares a queen on a square attacks given the occupied squares
+func QueenAttacks(s int, occupied uint64) uint64 {
+	return RookAttacks(s, occupied) | BishopAttacks(s, occupied)
+}
+
+func (t *table) index(occupied uint64) uint64 {
+	return (occupied & t.mask) * t.number >> t.shift
+}
+
+func (t *table) lookup(occupied uint64) uint64 {
+	return t.attacks[t.index(occupied)]
+}
+
+// newTable fills in the attack table of a slider moving along a set of steps from a square, using a magic number
+// generated by findMagic
+func newTable(s int, steps [][2]int, number uint64) table {
+	t := table{mask: relevantMask(s, steps), number: number}
+	t.shift = uint(64 - bits.OnesCount64(t.mask))
+	t.attacks = make([]uint64, 1<<(64-t.shift))
+	for occupied := uint64(0); ; occupied = (occupied - t.mask) & t.mask {
+		attacks := slidingAttacks(s, steps, occupied)
+		// a slider always attacks at least one square, so an empty entry has not been filled yet
+		entry := &t.attacks[t.index(occupied)]
+		if *entry != 0 && *entry != attacks {
+			panic("magic: magic numbers do not match the tables, run go generate")
+		}
+		*entry = attacks
+		if occupied == t.mask {
+			break
+		}
+	}
+	return t
+}
+
+// relevantMask returns the squares whose occupancy can change the attacks of a slider on a square. The square at the
+// end of each ray is attacked whether or not it is occupied, so it is left out
+func relevantMask(s int, steps [][2]int) uint64 {
+	var mask uint64
+	for _, step := range steps {
+		row, col := s/boardSize+step[0], s%boardSize+step[1]
+		for onBoard(row+step[0], col+step[1]) {
+			mask |= 1 << uint(row*boardSize+col)
+			row, col = row+step[0], col+step[1]
+		}
+	}
+	return mask
+}
+
+// slidingAttacks returns the squares attacked along a set of steps from a square, up to and including the first
+// occupied square along each. It walks each ray one square at a time, so it is only used to fill in the tables
+func slidingAttacks(s int, steps [][2]int, occupied uint64) uint64 {
+	var attacks uint64
+	for _, step := range steps {
+		row, col := s/boardSize+step[0], s%boardSize+step[1]
+		for onBoard(row, col) {
+			bit := uint64(1) << uint(row*boardSize+col)
+			attacks |= bit
+			if occupied&bit != 0 {
+				break
+			}
+			row, col = row+step[0], col+step[1]
+		}
+	}
+	return attacks
+}
+
+func onBoard(row, col int) bool {
+	return row >= 0 && row < boardSize && col >= 0 && col < boardSize
+}
@@ -0,0 +1,126 @@
+package magic
+
+import (
+	"bytes"
+	"chess/internal/xorshift"
+	"flag"
+	"fmt"
+	"go/format"
+	"math/bits"
+	"os"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerate numbers.go")
+
+// magicSeeds seed the search for magic numbers on each rank. They are fixed so that the same numbers are found on
+// every run, and chosen so that the search finishes quickly
+var magicSeeds = [boardSize]uint64{728, 10316, 55013, 32803, 12281, 15100, 16645, 255}
+
+// TestMagicNumbers checks that numbers.go holds the numbers findMagic finds, and rewrites it when run with -update
+func TestMagicNumbers(t *testing.T) {
+	var rook, bishop [64]uint64
+	for s := 0; s < 64; s++ {
+		rook[s] = findMagic(s, rookSteps)
+		bishop[s] = findMagic(s, bishopSteps)
+	}
+
+	if *update {
+		var buf bytes.Buffer
+		buf.WriteString("// Code generated by go test -run TestMagicNumbers -update; DO NOT EDIT.\n\n")
+		buf.WriteString("package magic\n\n")
+		buf.WriteString("// rookNumbers and bishopNumbers hold the magic number of each square, as found by findMagic\n")
+		writeNumbers(&buf, "rookNumbers", rook)
+		buf.WriteString("\n")
+		writeNumbers(&buf, "bishopNumbers", bishop)
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := os.WriteFile("numbers.go", src, 0644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+
+	if rook != rookNumbers || bishop != bishopNumbers {
+		t.Errorf("numbers.go does not hold the numbers findMagic finds, run go generate")
+	}
+}
+
+func TestAttacks(t *testing.T) {
+	// occupancies built from a fixed sequence so that failures can be reproduced
+	occupancies := []uint64{0, ^uint64(0)}
+	x := xorshift.Source(88172645463325252)
+	for i := 0; i < 200; i++ {
+		occupancies = append(occupancies, x.Next()&x.Next())
+	}
+
+	for s := 0; s < 64; s++ {
+		for _, occupied := range occupancies {
+			if expected, actual := slidingAttacks(s, rookSteps, occupied), RookAttacks(s, occupied); expected != actual {
+				t.Fatalf("rook on %d with %x: expected %x, got %x", s, occupied, expected, actual)
+			}
+			if expected, actual := slidingAttacks(s, bishopSteps, occupied), BishopAttacks(s, occupied); expected != actual {
+				t.Fatalf("bishop on %d with %x: expected %x, got %x", s, occupied, expected, actual)
+			}
+			if QueenAttacks(s, occupied) != RookAttacks(s, occupied)|BishopAttacks(s, occupied) {
+				t.Fatalf("queen on %d with %x: expected the rook and bishop attacks combined", s, occupied)
+			}
+		}
+	}
+}
+
+// findMagic searches for a magic number that maps every occupancy relevant to a slider on a square to an index
+// holding its attacks
+func findMagic(s int, steps [][2]int) uint64 {
+	// enumerate every subset of the mask along with the attacks it produces
+	mask := relevantMask(s, steps)
+	var occupancies, attacks []uint64
+	for subset := uint64(0); ; subset = (subset - mask) & mask {
+		occupancies = append(occupancies, subset)
+		attacks = append(attacks, slidingAttacks(s, steps, subset))
+		if subset == mask {
+			break
+		}
+	}
+
+	rng := xorshift.Source(magicSeeds[s/boardSize])
+	t := table{mask: mask, shift: uint(64 - bits.OnesCount64(mask))}
+	t.attacks = make([]uint64, len(occupancies))
+	// usedIn records the attempt that last filled each index, which saves clearing the table between attempts
+	usedIn := make([]int, len(occupancies))
+	for attempt := 1; ; attempt++ {
+		// magic numbers with few bits set are found much sooner
+		t.number = rng.Next() & rng.Next() & rng.Next()
+		if bits.OnesCount64(mask*t.number>>56) < 6 {
+			continue
+		}
+		collision := false
+		for i, occupied := range occupancies {
+			index := t.index(occupied)
+			if usedIn[index] == attempt && t.attacks[index] != attacks[i] {
+				collision = true
+				break
+			}
+			usedIn[index] = attempt
+			t.attacks[index] = attacks[i]
+		}
+		if !collision {
+			return t.number
+		}
+	}
+}
+
+func writeNumbers(buf *bytes.Buffer, name string, numbers [64]uint64) {
+	fmt.Fprintf(buf, "var %s = [64]uint64{\n", name)
+	for i, n := range numbers {
+		fmt.Fprintf(buf, "%#016x,", n)
+		if i%4 == 3 {
+			buf.WriteString("\n")
+		} else {
+			buf.WriteString(" ")
+		}
+	}
+	buf.WriteString("}\n")
+}
@@ -0,0 +1,158 @@
+package bitboard
+
+import (
+	"chess/game/setup"
+	"chess/pieces"
+)
+
+// LegalMoves returns all of the legal moves for the color to move. A pawn reaching the last rank yields one move for
+// each piece it can be promoted to
+func (pos *Position) LegalMoves() []Move {
+	us := pos.turn
+	moves := pos.pseudoLegalMoves()
+	legal := moves[:0]
+	for _, m := range moves {
+		u := pos.MakeMove(m)
+		if !pos.kingInCheck(us) {
+			legal = append(legal, m)
+		}
+		pos.UnmakeMove(m, u)
+	}
+	return legal
+}
+
+// pseudoLegalMoves returns the moves for the color to move without checking whether they leave the king in check.
+// Castling moves are only generated when the king does not pass through check
+func (pos *Position) pseudoLegalMoves() []Move {
+	us := pos.turn
+	own, occupied := pos.occupied[us], pos.Occupied()
+	moves := make([]Move, 0, 64)
+
+	moves = pos.appendPawnMoves(moves)
+	for kind := knight; kind < numKinds; kind++ {
+		for from := pos.pieces[us][kind]; from != 0; {
+			s := from.pop()
+			for to := pieceAttacks(kind, s, occupied) &^ own; to != 0; {
+				moves = append(moves, Move{From: s, To: to.pop()})
+			}
+		}
+	}
+	return pos.appendCastlingMoves(moves)
+}
+
+// pieceAttacks returns the squares attacked by a piece other than a pawn
+func pieceAttacks(kind int, s Square, occupied Bitboard) Bitboard {
+	switch kind {
+	case knight:
+		return knightAttacks[s]
+	case bishop:
+		return BishopAttacks(s, occupied)
+	case rook:
+		return RookAttacks(s, occupied)
+	case queen:
+		return QueenAttacks(s, occupied)
+	case king:
+		return kingAttacks[s]
+	default:
+		return 0
+	}
+}
+
+func (pos *Position) appendPawnMoves(moves []Move) []Move {
+	us := pos.turn
+	empty := ^pos.Occupied()
+	forward, secondRank, lastRank := boardSize, setup.WhiteSecondRank, setup.BlackFirstRank
+	if us == pieces.BLACK {
+		forward, secondRank, lastRank = -boardSize, setup.BlackSecondRank, setup.WhiteFirstRank
+	}
+
+	targets := pos.occupied[us.Opponent()]
+	// the en passant target can only be captured when the pawn that passed over it is still there
+	if pos.enPassant != NoSquare && pos.pieces[us.Opponent()][pawn].Has(enPassantCaptureSquare(pos.enPassant, us)) {
+		targets |= squareBit(pos.enPassant)
+	}
+
+	for from := pos.pieces[us][pawn]; from != 0; {
+		s := from.pop()
+		var to Bitboard
+		if push := s + Square(forward); empty.Has(push) {
+			to |= squareBit(push)
+			if double := push + Square(forward); s.row() == secondRank && empty.Has(double) {
+				moves = append(moves, Move{From: s, To: double, Flags: pieces.DoublePush})
+			}
+		}
+		to |= pawnAttacks[us][s] & targets
+
+		for to != 0 {
+			m := Move{From: s, To: to.pop()}
+			if m.To == pos.enPassant {
+				m.Flags |= pieces.EnPassant
+			}
+			if m.To.row() != lastRank {
+				moves = append(moves, m)
+				continue
+			}
+			for _, pr := range pieces.Promotions {
+				m.Promotion = pr
+				moves = append(moves, m)
+			}
+		}
+	}
+	return moves
+}
+
+func (pos *Position) appendCastlingMoves(moves []Move) []Move {
+	us := pos.turn
+	kings := pos.pieces[us][king]
+	if kings == 0 {
+		return moves
+	}
+	from := kings.first()
+	rank := Square(from.row() * boardSize)
+
+	for _, queenside := range []bool{false, true} {
+		if pos.castling&castlingRight(queenside, us) == 0 {
+			continue
+		}
+		m := Move{From: from, To: rank + Square(setup.StandardCastleColumn), Flags: pieces.KingsideCastle}
+		if queenside {
+			m.To, m.Flags = rank+Square(setup.QueensideCastleColumn), pieces.QueensideCastle
+		}
+		rookFrom, _ := castlingRookSquares(m)
+
+		// the squares between the king and the rook must be empty
+		if between(from, rookFrom)&pos.Occupied() != 0 {
+			continue
+		}
+		// the king cannot castle out of, through or into check
+		if pos.pathAttacked(from, m.To) {
+			continue
+		}
+		moves = append(moves, m)
+	}
+	return moves
+}
+
+// pathAttacked returns whether any square from one square to another on the same rank, inclusive, is attacked by the
+// opponent of the color to move
+func (pos *Position) pathAttacked(from, to Square) bool {
+	path := between(from, to) | squareBit(from) | squareBit(to)
+	for path != 0 {
+		if pos.IsAttacked(path.pop(), pos.turn.Opponent()) {
+			return true
+		}
+	}
+	return false
+}
+
+// between returns the squares strictly between two squares on the same rank
+func between(a, b Square) Bitboard {
+	if a > b {
+		a, b = b, a
+	}
+	var squares Bitboard
+	for s := a + 1; s < b; s++ {
+		squares |= squareBit(s)
+	}
+	return squares
+}
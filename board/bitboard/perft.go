@@ -0,0 +1,48 @@
+package bitboard
+
+import "sort"
+
+// DivideResult is the number of positions reached below one of the legal moves of a position
+type DivideResult struct {
+	Move  Move
+	Nodes uint64
+}
+
+// Perft returns the number of positions reached by playing every sequence of legal moves of a given length. Comparing
+// the count against known results checks the move generator
+func (pos *Position) Perft(depth int) uint64 {
+	if depth == 0 {
+		return 1
+	}
+	moves := pos.LegalMoves()
+	if depth == 1 {
+		return uint64(len(moves))
+	}
+
+	var nodes uint64
+	for _, m := range moves {
+		u := pos.MakeMove(m)
+		nodes += pos.Perft(depth - 1)
+		pos.UnmakeMove(m, u)
+	}
+	return nodes
+}
+
+// Divide returns the perft count below each legal move, ordered by move, which narrows a wrong count down to the
+// moves that cause it
+func (pos *Position) Divide(depth int) []DivideResult {
+	var results []DivideResult
+	if depth < 1 {
+		return results
+	}
+	for _, m := range pos.LegalMoves() {
+		u := pos.MakeMove(m)
+		results = append(results, DivideResult{Move: m, Nodes: pos.Perft(depth - 1)})
+		pos.UnmakeMove(m, u)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Move.String() < results[j].Move.String()
+	})
+	return results
+}
@@ -0,0 +1,57 @@
+package bitboard
+
+import (
+	"chess/board/location"
+	"chess/pieces"
+)
+
+// GenerateMoves returns the same moves as pieces.GenerateMoves for a given color, found using bitboards
+func GenerateMoves(c pieces.PieceColor, pcs []pieces.Piece, enPassant *location.Location) []pieces.Move {
+	pos := FromPieces(pcs, c, enPassant)
+	var bySquare [64]pieces.Piece
+	for _, p := range pcs {
+		bySquare[SquareOf(p.Location())] = p
+	}
+
+	var moves []pieces.Move
+	for _, m := range pos.LegalMoves() {
+		captureSquare := m.To
+		if m.Flags&pieces.EnPassant != 0 {
+			captureSquare = enPassantCaptureSquare(m.To, c)
+		}
+		moves = append(moves, pieces.Move{
+			From:      m.From.Location(),
+			To:        m.To.Location(),
+			Piece:     bySquare[m.From],
+			Captured:  bySquare[captureSquare],
+			Promotion: m.Promotion,
+			Flags:     m.Flags,
+		})
+	}
+	return moves
+}
+
+// LegalMoves returns the same locations as pieces.LegalMoves for a single piece, found using bitboards
+func LegalMoves(p pieces.Piece, pcs []pieces.Piece, enPassant *location.Location) []location.Location {
+	pos := FromPieces(pcs, p.Color(), enPassant)
+	from := SquareOf(p.Location())
+
+	var moves []location.Location
+	for _, m := range pos.LegalMoves() {
+		// promotions to each kind of piece share a destination
+		if m.From == from && (m.Promotion == pieces.NoPromotion || m.Promotion == pieces.PromoteToQueen) {
+			moves = append(moves, m.To.Location())
+		}
+	}
+	return moves
+}
+
+// IsAttacked returns the same result as pieces.IsAttacked, found using bitboards
+func IsAttacked(loc location.Location, attacker pieces.PieceColor, pcs []pieces.Piece) bool {
+	return FromPieces(pcs, attacker.Opponent(), nil).IsAttacked(SquareOf(loc), attacker)
+}
+
+// MoveOf returns the bitboard move for a move of a piece
+func MoveOf(m pieces.Move) Move {
+	return Move{From: SquareOf(m.From), To: SquareOf(m.To), Promotion: m.Promotion, Flags: m.Flags}
+}
@@ -0,0 +1,327 @@
+package bitboard
+
+import (
+	"chess/board/location"
+	"chess/game/setup"
+	"chess/pieces"
+)
+
+// kinds of piece, used to index the bitboards of a position
+const (
+	pawn     = int(pieces.PawnKind)
+	knight   = int(pieces.KnightKind)
+	bishop   = int(pieces.BishopKind)
+	rook     = int(pieces.RookKind)
+	queen    = int(pieces.QueenKind)
+	king     = int(pieces.KingKind)
+	numKinds = king + 1
+	noKind   = -1
+)
+
+// castling rights, one bit for each side of each color
+const (
+	whiteKingside uint8 = 1 << iota
+	whiteQueenside
+	blackKingside
+	blackQueenside
+)
+
+// Position is a chess position stored as one bitboard for each kind and color of piece
+type Position struct {
+	pieces    [2][numKinds]Bitboard
+	occupied  [2]Bitboard
+	turn      pieces.PieceColor
+	castling  uint8
+	enPassant Square
+	hash      uint64
+}
+
+// Move is a move in a bitboard position
+type Move struct {
+	From      Square
+	To        Square
+	Promotion pieces.Promotion
+	Flags     pieces.MoveFlag
+}
+
+func (m Move) String() string {
+	s := m.From.String() + "-" + m.To.String()
+	if m.Promotion != pieces.NoPromotion {
+		s += "=" + m.Promotion.String()
+	}
+	return s
+}
+
+// Undo holds the state a move destroys so that it can be unmade
+type Undo struct {
+	captured  int
+	castling  uint8
+	enPassant Square
+}
+
+// FromPieces returns the position of a set of pieces with a given color to move and en passant target, which may be
+// nil. Castling rights are taken from the kings and rooks that have not moved
+func FromPieces(pcs []pieces.Piece, turn pieces.PieceColor, enPassant *location.Location) *Position {
+	pos := &Position{turn: turn, enPassant: NoSquare}
+	for _, p := range pcs {
+		pos.put(p.Color(), int(p.Kind()), SquareOf(p.Location()))
+
+		if k, isKing := p.(*pieces.King); isKing {
+			if k.HasCastlingRight(false, pcs) {
+				pos.castling |= castlingRight(false, k.Color())
+			}
+			if k.HasCastlingRight(true, pcs) {
+				pos.castling |= castlingRight(true, k.Color())
+			}
+		}
+	}
+	if enPassant != nil {
+		pos.enPassant = SquareOf(*enPassant)
+	}
+	pos.hash ^= pos.stateHash()
+	return pos
+}
+
+// Pieces returns the pieces of the position. Kings and rooks that have lost their castling rights and pawns off
+// their starting rank are marked as having moved, so that the pieces follow the same rules as the position and
+// FromPieces gives back the same position
+func (pos *Position) Pieces() []pieces.Piece {
+	var pcs []pieces.Piece
+	for _, c := range []pieces.PieceColor{pieces.WHITE, pieces.BLACK} {
+		for kind := pawn; kind < numKinds; kind++ {
+			for _, s := range pos.pieces[c][kind].Squares() {
+				p := pieces.NewPiece(pieces.Kind(kind), s.Location(), c)
+				if pos.hasMoved(c, kind, s) {
+					p.Unmove(p.Location(), true)
+				}
+				pcs = append(pcs, p)
+			}
+		}
+	}
+	return pcs
+}
+
+// hasMoved returns whether a piece on a square must be treated as having moved
+func (pos *Position) hasMoved(c pieces.PieceColor, kind int, s Square) bool {
+	rights := castlingRight(false, c) | castlingRight(true, c)
+	switch kind {
+	case pawn:
+		secondRank := setup.WhiteSecondRank
+		if c == pieces.BLACK {
+			secondRank = setup.BlackSecondRank
+		}
+		return s.row() != secondRank
+	case king:
+		return pos.castling&rights == 0
+	case rook:
+		return pos.castling&rights&castlingRightsLost(s) == 0
+	default:
+		return false
+	}
+}
+
+// Turn returns the color to move
+func (pos *Position) Turn() pieces.PieceColor {
+	return pos.turn
+}
+
+// PieceAt returns the kind and color of the piece on a square, and whether there is one
+func (pos *Position) PieceAt(s Square) (pieces.Kind, pieces.PieceColor, bool) {
+	for _, c := range []pieces.PieceColor{pieces.WHITE, pieces.BLACK} {
+		if kind := pos.kindAt(c, s); kind != noKind {
+			return pieces.Kind(kind), c, true
+		}
+	}
+	return 0, 0, false
+}
+
+// HasCastlingRight returns whether a color keeps the right to castle towards a given side. It does not consider
+// whether castling is currently possible
+func (pos *Position) HasCastlingRight(c pieces.PieceColor, queenside bool) bool {
+	return pos.castling&castlingRight(queenside, c) != 0
+}
+
+// EnPassantTarget returns the square a pawn passed over with a double push on the previous move, or NoSquare if
+// there is none
+func (pos *Position) EnPassantTarget() Square {
+	return pos.enPassant
+}
+
+// Occupied returns the squares occupied by pieces of either color
+func (pos *Position) Occupied() Bitboard {
+	return pos.occupied[pieces.WHITE] | pos.occupied[pieces.BLACK]
+}
+
+// IsAttacked returns whether a square is attacked by any piece of a given color
+func (pos *Position) IsAttacked(s Square, attacker pieces.PieceColor) bool {
+	theirs := &pos.pieces[attacker]
+	// a pawn attacks a square if a pawn of the other color on that square would attack the pawn
+	if pawnAttacks[attacker.Opponent()][s]&theirs[pawn] != 0 ||
+		knightAttacks[s]&theirs[knight] != 0 ||
+		kingAttacks[s]&theirs[king] != 0 {
+		return true
+	}
+	occupied := pos.Occupied()
+	return BishopAttacks(s, occupied)&(theirs[bishop]|theirs[queen]) != 0 ||
+		RookAttacks(s, occupied)&(theirs[rook]|theirs[queen]) != 0
+}
+
+// InCheck returns whether the king of the color to move is attacked
+func (pos *Position) InCheck() bool {
+	return pos.kingInCheck(pos.turn)
+}
+
+func (pos *Position) kingInCheck(c pieces.PieceColor) bool {
+	kings := pos.pieces[c][king]
+	return kings != 0 && pos.IsAttacked(kings.first(), c.Opponent())
+}
+
+// MakeMove makes a legal move for the color to move, returning what is needed to unmake it
+func (pos *Position) MakeMove(m Move) Undo {
+	us, them := pos.turn, pos.turn.Opponent()
+	u := Undo{captured: noKind, castling: pos.castling, enPassant: pos.enPassant}
+	pos.hash ^= pos.stateHash()
+
+	captureSquare := m.To
+	if m.Flags&pieces.EnPassant != 0 {
+		captureSquare = enPassantCaptureSquare(m.To, us)
+	}
+	if captured := pos.kindAt(them, captureSquare); captured != noKind {
+		pos.remove(them, captured, captureSquare)
+		u.captured = captured
+	}
+
+	moved := pos.kindAt(us, m.From)
+	pos.remove(us, moved, m.From)
+	if m.Promotion != pieces.NoPromotion {
+		moved = int(m.Promotion.Kind())
+	}
+	pos.put(us, moved, m.To)
+
+	if m.Flags&(pieces.KingsideCastle|pieces.QueensideCastle) != 0 {
+		from, to := castlingRookSquares(m)
+		pos.remove(us, rook, from)
+		pos.put(us, rook, to)
+	}
+
+	if moved == king {
+		pos.castling &^= castlingRight(false, us) | castlingRight(true, us)
+	}
+	pos.castling &^= castlingRightsLost(m.From) | castlingRightsLost(m.To)
+	pos.enPassant = NoSquare
+	if m.Flags&pieces.DoublePush != 0 {
+		pos.enPassant = (m.From + m.To) / 2
+	}
+	pos.turn = them
+	pos.hash ^= pos.stateHash()
+	return u
+}
+
+// UnmakeMove takes back the last move made with MakeMove
+func (pos *Position) UnmakeMove(m Move, u Undo) {
+	them, us := pos.turn, pos.turn.Opponent()
+	pos.hash ^= pos.stateHash()
+	pos.turn = us
+
+	if m.Flags&(pieces.KingsideCastle|pieces.QueensideCastle) != 0 {
+		from, to := castlingRookSquares(m)
+		pos.remove(us, rook, to)
+		pos.put(us, rook, from)
+	}
+
+	moved := pos.kindAt(us, m.To)
+	pos.remove(us, moved, m.To)
+	if m.Promotion != pieces.NoPromotion {
+		moved = pawn
+	}
+	pos.put(us, moved, m.From)
+
+	if u.captured != noKind {
+		captureSquare := m.To
+		if m.Flags&pieces.EnPassant != 0 {
+			captureSquare = enPassantCaptureSquare(m.To, us)
+		}
+		pos.put(them, u.captured, captureSquare)
+	}
+
+	pos.castling = u.castling
+	pos.enPassant = u.enPassant
+	pos.hash ^= pos.stateHash()
+}
+
+// stateHash returns the part of the hash that does not come from the placement of the pieces
+func (pos *Position) stateHash() uint64 {
+	return pos.sideHash() ^ castlingKeys[pos.castling] ^ pos.enPassantHash()
+}
+
+func (pos *Position) put(c pieces.PieceColor, kind int, s Square) {
+	pos.pieces[c][kind] |= squareBit(s)
+	pos.occupied[c] |= squareBit(s)
+	pos.hash ^= pieceKeys[c][kind][s]
+}
+
+func (pos *Position) remove(c pieces.PieceColor, kind int, s Square) {
+	pos.pieces[c][kind] &^= squareBit(s)
+	pos.occupied[c] &^= squareBit(s)
+	pos.hash ^= pieceKeys[c][kind][s]
+}
+
+// kindAt returns the kind of the piece of a given color on a square, or noKind if there is none
+func (pos *Position) kindAt(c pieces.PieceColor, s Square) int {
+	if !pos.occupied[c].Has(s) {
+		return noKind
+	}
+	for kind := pawn; kind < numKinds; kind++ {
+		if pos.pieces[c][kind].Has(s) {
+			return kind
+		}
+	}
+	return noKind
+}
+
+// enPassantCaptureSquare returns the square of the pawn captured by a pawn of a given color moving to an en passant
+// target
+func enPassantCaptureSquare(target Square, c pieces.PieceColor) Square {
+	if c == pieces.WHITE {
+		return target - boardSize
+	}
+	return target + boardSize
+}
+
+func castlingRight(queenside bool, c pieces.PieceColor) uint8 {
+	switch {
+	case c == pieces.WHITE && !queenside:
+		return whiteKingside
+	case c == pieces.WHITE:
+		return whiteQueenside
+	case !queenside:
+		return blackKingside
+	default:
+		return blackQueenside
+	}
+}
+
+// castlingRightsLost returns the castling rights lost when a piece moves from or to a rook's starting corner
+func castlingRightsLost(s Square) uint8 {
+	switch s.Location() {
+	case location.Location{Row: setup.WhiteFirstRank, Col: setup.KingsideRookColumn}:
+		return whiteKingside
+	case location.Location{Row: setup.WhiteFirstRank, Col: setup.QueensideRookColumn}:
+		return whiteQueenside
+	case location.Location{Row: setup.BlackFirstRank, Col: setup.KingsideRookColumn}:
+		return blackKingside
+	case location.Location{Row: setup.BlackFirstRank, Col: setup.QueensideRookColumn}:
+		return blackQueenside
+	default:
+		return 0
+	}
+}
+
+// castlingRookSquares returns the squares the rook moves from and to when castling
+func castlingRookSquares(m Move) (Square, Square) {
+	rank := Square(m.To.row() * boardSize)
+	if m.Flags&pieces.QueensideCastle != 0 {
+		return rank + Square(setup.QueensideRookColumn), rank + Square(setup.QueensideCastleRookColumn)
+	}
+	return rank + Square(setup.KingsideRookColumn), rank + Square(setup.StandardCastleRookColumn)
+}
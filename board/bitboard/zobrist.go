@@ -0,0 +1,76 @@
+package bitboard
+
+import (
+	"chess/internal/xorshift"
+	"chess/pieces"
+)
+
+// zobristSeed seeds the Zobrist keys so that a position hashes to the same value on every run
+const zobristSeed = 0x9e3779b97f4a7c15
+
+// Zobrist keys, which are combined with exclusive or to hash a position
+var (
+	pieceKeys [2][numKinds][64]uint64
+	// blackToMoveKey is included when black is to move
+	blackToMoveKey uint64
+	// castlingKeys is indexed by a set of castling rights, and combines the keys of each right in the set
+	castlingKeys [16]uint64
+	// enPassantKeys is indexed by the file of the en passant target
+	enPassantKeys [boardSize]uint64
+)
+
+func init() {
+	rng := xorshift.Source(zobristSeed)
+	for c := range pieceKeys {
+		for kind := range pieceKeys[c] {
+			for s := range pieceKeys[c][kind] {
+				pieceKeys[c][kind][s] = rng.Next()
+			}
+		}
+	}
+	blackToMoveKey = rng.Next()
+
+	var rightKeys [4]uint64
+	for i := range rightKeys {
+		rightKeys[i] = rng.Next()
+	}
+	for rights := range castlingKeys {
+		for i, key := range rightKeys {
+			if rights&(1<<uint(i)) != 0 {
+				castlingKeys[rights] ^= key
+			}
+		}
+	}
+
+	for file := range enPassantKeys {
+		enPassantKeys[file] = rng.Next()
+	}
+}
+
+// Hash returns the Zobrist hash of the position, which covers the placement of the pieces, the color to move, the
+// castling rights and the file of the en passant target. Positions that are the same in all of these hash to the same
+// value on every run
+func (pos *Position) Hash() uint64 {
+	return pos.hash
+}
+
+// enPassantHash returns the part of the hash for the en passant target. The target is only included when a pawn of
+// the color to move is next to the pawn that can be captured, as otherwise it makes no difference to the position
+func (pos *Position) enPassantHash() uint64 {
+	if pos.enPassant == NoSquare {
+		return 0
+	}
+	// a pawn can capture en passant from the squares a pawn of the other color on the target would attack
+	if pawnAttacks[pos.turn.Opponent()][pos.enPassant]&pos.pieces[pos.turn][pawn] == 0 {
+		return 0
+	}
+	return enPassantKeys[pos.enPassant.col()]
+}
+
+// sideHash returns the part of the hash for the color to move
+func (pos *Position) sideHash() uint64 {
+	if pos.turn == pieces.BLACK {
+		return blackToMoveKey
+	}
+	return 0
+}
@@ -1,6 +1,9 @@
 package board
 
-import "chess/board/location"
+import (
+	"chess/board/location"
+	"chess/pieces"
+)
 
 const (
 	DEFAULT_BOARD_SIZE int = 8
@@ -17,8 +20,9 @@ func NewBoard() *Board {
 		board[row] = make([]*tile, DEFAULT_BOARD_SIZE)
 	}
 
-	isWhiteTile := false
 	for row := 0; row < DEFAULT_BOARD_SIZE; row++ {
+		// colors alternate along each row and each column, with a black tile in the first corner
+		isWhiteTile := row%2 == 1
 		for col := 0; col < DEFAULT_BOARD_SIZE; col++ {
 			if isWhiteTile {
 				board[row][col] = NewTile(WHITE)
@@ -40,3 +44,66 @@ func (b *Board) GetTile(loc location.Location) *tile {
 func (b *Board) IsPieceAtLocation(loc location.Location) bool {
 	return (*b)[loc.GetRow()][loc.GetCol()].HasPiece()
 }
+
+// PieceAt returns the piece at a given location, or nil if the location is vacant or off the board
+func (b *Board) PieceAt(loc location.Location) pieces.Piece {
+	if !loc.IsValid(DEFAULT_BOARD_SIZE) {
+		return nil
+	}
+	return b.GetTile(loc).GetPiece()
+}
+
+// PlacePiece places a piece on the tile at the piece's location, replacing any piece already there
+func (b *Board) PlacePiece(p pieces.Piece) {
+	b.GetTile(p.Location()).SetPiece(p)
+}
+
+// RemovePiece removes the piece at a given location from the board and returns it, or returns nil if the location
+// is vacant
+func (b *Board) RemovePiece(loc location.Location) pieces.Piece {
+	t := b.GetTile(loc)
+	p := t.GetPiece()
+	t.SetPiece(nil)
+	return p
+}
+
+// MovePiece moves the piece at from to to and returns the piece captured at to, or nil if to was vacant
+func (b *Board) MovePiece(from, to location.Location) pieces.Piece {
+	p := b.RemovePiece(from)
+	if p == nil {
+		return nil
+	}
+	captured := b.RemovePiece(to)
+	p.Move(to)
+	b.PlacePiece(p)
+	return captured
+}
+
+// Pieces returns all of the pieces on the board, ordered by row and then column
+func (b *Board) Pieces() []pieces.Piece {
+	var pcs []pieces.Piece
+	for _, row := range *b {
+		for _, t := range row {
+			if t.HasPiece() {
+				pcs = append(pcs, t.GetPiece())
+			}
+		}
+	}
+	return pcs
+}
+
+// Clone returns a copy of the board holding copies of its pieces, so that changes to either board or its pieces do
+// not affect the other
+func (b *Board) Clone() *Board {
+	clone := make(Board, len(*b))
+	for row := range *b {
+		clone[row] = make([]*tile, len((*b)[row]))
+		for col, t := range (*b)[row] {
+			clone[row][col] = NewTile(t.color)
+			if t.HasPiece() {
+				clone[row][col].SetPiece(t.piece.Clone())
+			}
+		}
+	}
+	return &clone
+}
@@ -1,12 +1,19 @@
 package board
 
-import "testing"
+import (
+	"chess/board/location"
+	"chess/pieces"
+	"testing"
+)
 
 func TestNewBoard(t *testing.T) {
 	b := NewBoard()
 
-	expectedColor := BLACK
 	for row := 0; row < len(*b); row++ {
+		expectedColor := BLACK
+		if row%2 == 1 {
+			expectedColor = WHITE
+		}
 		for col := 0; col < len((*b)[row]); col++ {
 			if (*b)[row][col].GetColor() != expectedColor {
 				t.Error("unexpected tileColor")
@@ -19,3 +26,70 @@ func TestNewBoard(t *testing.T) {
 		}
 	}
 }
+
+func TestPlaceAndRemovePiece(t *testing.T) {
+	b := NewBoard()
+	loc := location.Location{Row: 3, Col: 4}
+	p := pieces.NewKnight(loc, pieces.WHITE)
+
+	if b.IsPieceAtLocation(loc) {
+		t.Fatalf("expected new board to be empty")
+	}
+	b.PlacePiece(p)
+	if !b.IsPieceAtLocation(loc) || b.PieceAt(loc) != p {
+		t.Errorf("expected knight at %v", loc)
+	}
+	if len(b.Pieces()) != 1 {
+		t.Errorf("expected 1 piece, got %d", len(b.Pieces()))
+	}
+	if removed := b.RemovePiece(loc); removed != p {
+		t.Errorf("expected to remove knight, got %v", removed)
+	}
+	if b.IsPieceAtLocation(loc) || len(b.Pieces()) != 0 {
+		t.Errorf("expected board to be empty after removing piece")
+	}
+}
+
+func TestMovePiece(t *testing.T) {
+	b := NewBoard()
+	from := location.Location{Row: 0, Col: 0}
+	to := location.Location{Row: 5, Col: 0}
+	r := pieces.NewRook(from, pieces.WHITE)
+	n := pieces.NewKnight(to, pieces.BLACK)
+	b.PlacePiece(r)
+	b.PlacePiece(n)
+
+	captured := b.MovePiece(from, to)
+	if captured != n {
+		t.Errorf("expected knight to be captured, got %v", captured)
+	}
+	if b.IsPieceAtLocation(from) || b.PieceAt(to) != r {
+		t.Errorf("expected rook to move from %v to %v", from, to)
+	}
+	if !r.Location().Equals(to) || !r.HasMoved() {
+		t.Errorf("expected rook location and hasMoved to be updated")
+	}
+	if len(b.Pieces()) != 1 {
+		t.Errorf("expected 1 piece, got %d", len(b.Pieces()))
+	}
+}
+
+func TestClone(t *testing.T) {
+	b := NewBoard()
+	loc := location.Location{Row: 0, Col: 1}
+	knight := pieces.NewKnight(loc, pieces.WHITE)
+	b.PlacePiece(knight)
+
+	clone := b.Clone()
+	if clone.PieceAt(loc) == nil || clone.PieceAt(loc) == knight {
+		t.Fatalf("expected clone to hold a copy of the knight")
+	}
+	if clone.GetTile(loc).GetColor() != b.GetTile(loc).GetColor() {
+		t.Errorf("expected clone to keep tile colors")
+	}
+
+	clone.MovePiece(loc, location.Location{Row: 2, Col: 2})
+	if b.PieceAt(loc) != knight || !knight.Location().Equals(loc) || knight.HasMoved() {
+		t.Errorf("expected original board and knight to be unchanged")
+	}
+}
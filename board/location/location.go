@@ -1,11 +1,33 @@
 package location
 
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrInvalidSquare is returned when a square name cannot be parsed
+var ErrInvalidSquare = errors.New("invalid square")
+
 // Location represents a location on a chess board
 type Location struct {
 	Row int
 	Col int
 }
 
+// ParseSquare returns the location with a given algebraic name, such as "e4". Files are lowercase letters starting
+// from "a" and ranks are numbers starting from 1, so boards larger than 8x8 are supported
+func ParseSquare(name string) (Location, error) {
+	if len(name) < 2 || name[0] < 'a' || name[0] > 'z' {
+		return Location{}, fmt.Errorf("%w: %q", ErrInvalidSquare, name)
+	}
+	rank, err := strconv.Atoi(name[1:])
+	if err != nil || rank < 1 || name[1] == '0' || name[1] == '+' {
+		return Location{}, fmt.Errorf("%w: %q", ErrInvalidSquare, name)
+	}
+	return Location{Row: rank - 1, Col: int(name[0] - 'a')}, nil
+}
+
 // GetRow returns the row of the location
 func (l Location) GetRow() int {
 	return l.Row
@@ -19,3 +41,17 @@ func (l Location) GetCol() int {
 func (l Location) Equals(other Location) bool {
 	return l.Row == other.Row && l.Col == other.Col
 }
+
+// IsValid returns whether the location is on a square board with a given number of rows and columns
+func (l Location) IsValid(boardSize int) bool {
+	return l.Row >= 0 && l.Row < boardSize &&
+		l.Col >= 0 && l.Col < boardSize
+}
+
+// String returns the algebraic name of the location, such as "e4"
+func (l Location) String() string {
+	if l.Row < 0 || l.Col < 0 || l.Col >= 26 {
+		return fmt.Sprintf("{%d %d}", l.Row, l.Col)
+	}
+	return string(rune('a'+l.Col)) + strconv.Itoa(l.Row+1)
+}
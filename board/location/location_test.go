@@ -0,0 +1,54 @@
+package location
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		loc      Location
+		expected string
+	}{
+		{Location{Row: 0, Col: 0}, "a1"},
+		{Location{Row: 3, Col: 4}, "e4"},
+		{Location{Row: 7, Col: 7}, "h8"},
+		{Location{Row: 9, Col: 9}, "j10"},
+	}
+	for _, test := range tests {
+		if test.loc.String() != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, test.loc.String())
+		}
+	}
+}
+
+func TestParseSquare(t *testing.T) {
+	for _, name := range []string{"a1", "e4", "h8", "j10", "l12"} {
+		loc, err := ParseSquare(name)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", name, err)
+			continue
+		}
+		if loc.String() != name {
+			t.Errorf("expected %q to round trip, got %q", name, loc.String())
+		}
+	}
+
+	for _, name := range []string{"", "e", "4e", "E4", "e0", "e-1", "e+4", "e04", "ee4"} {
+		if _, err := ParseSquare(name); !errors.Is(err, ErrInvalidSquare) {
+			t.Errorf("expected ErrInvalidSquare parsing %q, got %v", name, err)
+		}
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	if !(Location{Row: 7, Col: 7}).IsValid(8) {
+		t.Errorf("expected h8 to be valid on an 8x8 board")
+	}
+	if (Location{Row: 8, Col: 0}).IsValid(8) || (Location{Row: 0, Col: -1}).IsValid(8) {
+		t.Errorf("expected locations off the board to be invalid")
+	}
+	if !(Location{Row: 9, Col: 9}).IsValid(10) {
+		t.Errorf("expected j10 to be valid on a 10x10 board")
+	}
+}
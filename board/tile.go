@@ -11,7 +11,7 @@ const (
 
 type tile struct {
 	color tileColor
-	piece *pieces.Piece
+	piece pieces.Piece
 }
 
 func NewTile(tc tileColor) *tile {
@@ -22,10 +22,10 @@ func NewTile(tc tileColor) *tile {
 }
 
 func (t *tile) HasPiece() bool {
-	return t.piece == nil
+	return t.piece != nil
 }
 
-func (t *tile) GetPiece() *pieces.Piece {
+func (t *tile) GetPiece() pieces.Piece {
 	return t.piece
 }
 
@@ -33,6 +33,6 @@ func (t *tile) GetColor() tileColor {
 	return t.color
 }
 
-func (t *tile) SetPiece(p *pieces.Piece) {
+func (t *tile) SetPiece(p pieces.Piece) {
 	t.piece = p
 }
@@ -0,0 +1,83 @@
+// Perft counts the positions reached from a position by every sequence of legal moves of a given length, for checking
+// move generation against known results.
+//
+// Usage:
+//
+//	perft [-fen FEN] [-divide] [-pieces] depth
+package main
+
+import (
+	"chess/board/bitboard"
+	"chess/board/location"
+	"chess/game"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+func main() {
+	fen := flag.String("fen", game.StartingFEN, "FEN of the position to count from")
+	divide := flag.Bool("divide", false, "print the count below each legal move")
+	usePieces := flag.Bool("pieces", false, "use the move generation of the pieces package instead of bitboards")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [-fen FEN] [-divide] [-pieces] depth\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	depth, err := strconv.Atoi(flag.Arg(0))
+	if err != nil || depth < 0 {
+		fmt.Fprintf(os.Stderr, "depth must be a non-negative integer, got %q\n", flag.Arg(0))
+		os.Exit(2)
+	}
+
+	g, err := game.NewGameFromFEN(*fen)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	start := time.Now()
+	var nodes uint64
+	switch {
+	case *usePieces && *divide:
+		for _, result := range g.Divide(depth) {
+			fmt.Printf("%v: %d\n", result.Move, result.Nodes)
+			nodes += result.Nodes
+		}
+	case *usePieces:
+		nodes = g.Perft(depth)
+	case *divide:
+		for _, result := range position(g).Divide(depth) {
+			fmt.Printf("%v: %d\n", result.Move, result.Nodes)
+			nodes += result.Nodes
+		}
+	default:
+		nodes = position(g).Perft(depth)
+	}
+	elapsed := time.Since(start)
+
+	if *divide {
+		fmt.Println()
+	}
+	fmt.Printf("Nodes: %d\n", nodes)
+	fmt.Printf("Time: %v\n", elapsed.Round(time.Millisecond))
+	if seconds := elapsed.Seconds(); seconds > 0 {
+		fmt.Printf("Nodes per second: %.0f\n", float64(nodes)/seconds)
+	}
+}
+
+// position returns the bitboard position of a game
+func position(g *game.Game) *bitboard.Position {
+	var enPassant *location.Location
+	if target, ok := g.EnPassantTarget(); ok {
+		enPassant = &target
+	}
+	return bitboard.FromPieces(g.Pieces(), g.Turn(), enPassant)
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"chess/board"
+	"chess/pieces"
+	"errors"
+)
+
+// the number of moves by either side without a capture or pawn move after which a draw can be claimed, and after
+// which the game is drawn automatically
+const (
+	fiftyMoveHalfmoves       = 100
+	seventyFiveMoveHalfmoves = 150
+)
+
+// ErrNoDrawClaim is returned when a draw is claimed in a position where the rules do not allow one
+var ErrNoDrawClaim = errors.New("no draw can be claimed")
+
+// Repetitions returns the number of times the current position has occurred in the game, including now. Positions
+// are the same when they have the same placement, side to move, castling rights and en passant rights
+func (g *Game) Repetitions() int {
+	hash := g.position.Hash()
+	count := 1
+	for _, entry := range g.history {
+		if entry.hash == hash {
+			count++
+		}
+	}
+	return count
+}
+
+// CanClaimThreefoldRepetition returns whether the current position has occurred at least three times, which allows
+// either player to claim a draw. The game ends automatically when the position occurs a fifth time
+func (g *Game) CanClaimThreefoldRepetition() bool {
+	return !g.IsOver() && g.Repetitions() >= 3
+}
+
+// CanClaimFiftyMoveRule returns whether each side has made fifty moves without a capture or pawn move, which allows
+// either player to claim a draw. The game ends automatically after seventy-five moves
+func (g *Game) CanClaimFiftyMoveRule() bool {
+	return !g.IsOver() && g.halfmoveClock >= fiftyMoveHalfmoves
+}
+
+// ClaimDraw ends the game in a draw if the rules allow one to be claimed in the current position, by threefold
+// repetition or the fifty-move rule
+func (g *Game) ClaimDraw() error {
+	switch {
+	case g.IsOver():
+		return ErrGameOver
+	case g.CanClaimThreefoldRepetition():
+		g.termination = ThreefoldRepetition
+	case g.CanClaimFiftyMoveRule():
+		g.termination = FiftyMoveRule
+	default:
+		return ErrNoDrawClaim
+	}
+	g.result = Draw
+	return nil
+}
+
+// HasInsufficientMaterial returns whether neither side has the material to checkmate, which is when the kings are
+// joined by at most a single knight or bishop, or only by bishops that all stand on tiles of the same color
+func (g *Game) HasInsufficientMaterial() bool {
+	knights, bishops, whiteTileBishops := 0, 0, 0
+	for _, p := range g.Pieces() {
+		switch p.Kind() {
+		case pieces.KingKind:
+		case pieces.KnightKind:
+			knights++
+		case pieces.BishopKind:
+			bishops++
+			if g.board.GetTile(p.Location()).GetColor() == board.WHITE {
+				whiteTileBishops++
+			}
+		default:
+			return false
+		}
+	}
+
+	switch {
+	case knights+bishops <= 1:
+		return true
+	case knights == 0:
+		return whiteTileBishops == 0 || whiteTileBishops == bishops
+	default:
+		return false
+	}
+}
@@ -0,0 +1,297 @@
+package game
+
+import (
+	"chess/board"
+	"chess/board/bitboard"
+	"chess/board/location"
+	"chess/game/setup"
+	"chess/pieces"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// StartingFEN is the FEN of the standard starting position
+const StartingFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+// ErrInvalidFEN is returned, wrapped with a description of the problem, when a FEN string cannot be parsed
+var ErrInvalidFEN = errors.New("invalid FEN")
+
+// NewGameFromFEN returns a new game from the position described by a FEN string. The halfmove and fullmove counters
+// may be omitted, in which case they default to 0 and 1
+func NewGameFromFEN(fen string) (*Game, error) {
+	fields := strings.Fields(fen)
+	if len(fields) != 4 && len(fields) != 6 {
+		return nil, fenError("expected 4 or 6 fields, got %d", len(fields))
+	}
+
+	b := board.NewBoard()
+	if err := parsePlacement(fields[0], b); err != nil {
+		return nil, err
+	}
+	g := &Game{
+		board:          b,
+		fullmoveNumber: 1,
+	}
+
+	switch fields[1] {
+	case "w":
+		g.turn = pieces.WHITE
+	case "b":
+		g.turn = pieces.BLACK
+	default:
+		return nil, fenError("side to move must be \"w\" or \"b\", got %q", fields[1])
+	}
+
+	if err := parseCastling(fields[2], b); err != nil {
+		return nil, err
+	}
+
+	if fields[3] != "-" {
+		// the en passant square is behind a pawn of the side that just moved, which passed over it from its
+		// starting square
+		expectedRow, pawnRow, startingRow := setup.BlackThirdRank, setup.BlackFourthRank, setup.BlackSecondRank
+		if g.turn == pieces.BLACK {
+			expectedRow, pawnRow, startingRow = setup.WhiteThirdRank, setup.WhiteFourthRank, setup.WhiteSecondRank
+		}
+		loc, err := location.ParseSquare(fields[3])
+		if err != nil || !loc.IsValid(board.DEFAULT_BOARD_SIZE) || loc.GetRow() != expectedRow {
+			return nil, fenError("invalid en passant square %q", fields[3])
+		}
+		pawn := b.PieceAt(location.Location{Row: pawnRow, Col: loc.GetCol()})
+		if pawn == nil || pawn.Kind() != pieces.PawnKind || pawn.Color() == g.turn || b.IsPieceAtLocation(loc) ||
+			b.IsPieceAtLocation(location.Location{Row: startingRow, Col: loc.GetCol()}) {
+			return nil, fenError("en passant square %q is not behind a pawn that just moved", fields[3])
+		}
+		g.enPassant = &loc
+	}
+
+	if len(fields) == 6 {
+		halfmove, err := strconv.Atoi(fields[4])
+		if err != nil || halfmove < 0 {
+			return nil, fenError("halfmove clock must be a non-negative integer, got %q", fields[4])
+		}
+		fullmove, err := strconv.Atoi(fields[5])
+		if err != nil || fullmove < 1 {
+			return nil, fenError("fullmove number must be a positive integer, got %q", fields[5])
+		}
+		g.halfmoveClock = halfmove
+		g.fullmoveNumber = fullmove
+	}
+
+	g.position = bitboard.FromPieces(g.Pieces(), g.turn, g.enPassant)
+	g.updateResult()
+	g.initialFEN = g.FEN()
+	return g, nil
+}
+
+// FEN returns the FEN string describing the current position
+func (g *Game) FEN() string {
+	return FormatFEN(g.Pieces(), g.turn, g.enPassant, g.halfmoveClock, g.fullmoveNumber)
+}
+
+// FormatFEN returns the FEN string describing a position from its pieces, side to move, en passant target, which may
+// be nil, and move counters. Castling rights are taken from the kings and rooks that have not moved
+func FormatFEN(pcs []pieces.Piece, turn pieces.PieceColor, enPassant *location.Location, halfmoveClock,
+	fullmoveNumber int) string {
+	var placement [board.DEFAULT_BOARD_SIZE][board.DEFAULT_BOARD_SIZE]pieces.Piece
+	for _, p := range pcs {
+		placement[p.Location().GetRow()][p.Location().GetCol()] = p
+	}
+
+	var sb strings.Builder
+
+	// piece placement, from the eighth rank down to the first
+	for row := board.DEFAULT_BOARD_SIZE - 1; row >= 0; row-- {
+		empty := 0
+		for col := 0; col < board.DEFAULT_BOARD_SIZE; col++ {
+			p := placement[row][col]
+			if p == nil {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				sb.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			sb.WriteRune(p.Symbol())
+		}
+		if empty > 0 {
+			sb.WriteString(strconv.Itoa(empty))
+		}
+		if row > 0 {
+			sb.WriteByte('/')
+		}
+	}
+
+	if turn == pieces.WHITE {
+		sb.WriteString(" w ")
+	} else {
+		sb.WriteString(" b ")
+	}
+
+	sb.WriteString(castlingRights(pcs))
+
+	sb.WriteByte(' ')
+	if enPassant == nil {
+		sb.WriteByte('-')
+	} else {
+		sb.WriteString(enPassant.String())
+	}
+
+	fmt.Fprintf(&sb, " %d %d", halfmoveClock, fullmoveNumber)
+	return sb.String()
+}
+
+// castlingRights returns the castling rights of both sides in FEN notation
+func castlingRights(pcs []pieces.Piece) string {
+	rights := ""
+	for _, c := range []pieces.PieceColor{pieces.WHITE, pieces.BLACK} {
+		king := findKing(c, pcs)
+		if king == nil {
+			continue
+		}
+		if king.HasCastlingRight(false, pcs) {
+			rights += string(castlingSymbol(false, c))
+		}
+		if king.HasCastlingRight(true, pcs) {
+			rights += string(castlingSymbol(true, c))
+		}
+	}
+	if rights == "" {
+		return "-"
+	}
+	return rights
+}
+
+func parsePlacement(placement string, b *board.Board) error {
+	ranks := strings.Split(placement, "/")
+	if len(ranks) != board.DEFAULT_BOARD_SIZE {
+		return fenError("expected %d ranks, got %d", board.DEFAULT_BOARD_SIZE, len(ranks))
+	}
+
+	kings := map[pieces.PieceColor]int{}
+	for i, rank := range ranks {
+		row := board.DEFAULT_BOARD_SIZE - 1 - i
+		col := 0
+		for _, r := range rank {
+			if r >= '1' && r <= '8' {
+				col += int(r - '0')
+				continue
+			}
+			if col >= board.DEFAULT_BOARD_SIZE {
+				return fenError("rank %d has more than %d squares", row+1, board.DEFAULT_BOARD_SIZE)
+			}
+			p, err := pieces.NewPieceFromSymbol(r, location.Location{Row: row, Col: col})
+			// Unicode symbols are not part of FEN
+			if err != nil || r > unicode.MaxASCII {
+				return fenError("invalid piece %q on rank %d", r, row+1)
+			}
+			if p.Kind() == pieces.KingKind {
+				kings[p.Color()]++
+			}
+			if p.Kind() == pieces.PawnKind {
+				if row == setup.WhiteFirstRank || row == setup.BlackFirstRank {
+					return fenError("pawn on rank %d", row+1)
+				}
+				// pawns off their starting rank can no longer double push
+				if row != pawnStartingRank(p.Color()) {
+					markMoved(p)
+				}
+			}
+			b.PlacePiece(p)
+			col++
+		}
+		if col != board.DEFAULT_BOARD_SIZE {
+			return fenError("rank %d has %d squares, expected %d", row+1, col, board.DEFAULT_BOARD_SIZE)
+		}
+	}
+
+	if kings[pieces.WHITE] != 1 || kings[pieces.BLACK] != 1 {
+		return fenError("expected one king of each color, got %d white and %d black", kings[pieces.WHITE], kings[pieces.BLACK])
+	}
+	return nil
+}
+
+// parseCastling marks the kings and rooks that have lost their castling rights as having moved
+func parseCastling(castling string, b *board.Board) error {
+	rights := map[byte]bool{}
+	if castling != "-" {
+		for i := 0; i < len(castling); i++ {
+			r := castling[i]
+			if !strings.ContainsRune("KQkq", rune(r)) || rights[r] {
+				return fenError("invalid castling rights %q", castling)
+			}
+			rights[r] = true
+		}
+	}
+
+	pcs := b.Pieces()
+	for _, c := range []pieces.PieceColor{pieces.WHITE, pieces.BLACK} {
+		king := findKing(c, pcs)
+		for _, queenside := range []bool{false, true} {
+			has := rights[castlingSymbol(queenside, c)]
+			if has && !king.HasCastlingRight(queenside, pcs) {
+				return fenError("castling right %q requires an unmoved king and rook in place", castlingSymbol(queenside, c))
+			}
+			if !has {
+				if rook := b.PieceAt(castlingRookCorner(queenside, c)); rook != nil {
+					markMoved(rook)
+				}
+			}
+		}
+		if !rights[castlingSymbol(false, c)] && !rights[castlingSymbol(true, c)] {
+			markMoved(king)
+		}
+	}
+	return nil
+}
+
+func fenError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidFEN, fmt.Sprintf(format, args...))
+}
+
+// markMoved marks a piece as having moved without changing its location
+func markMoved(p pieces.Piece) {
+	p.Unmove(p.Location(), true)
+}
+
+func findKing(c pieces.PieceColor, pcs []pieces.Piece) *pieces.King {
+	for _, p := range pcs {
+		if k, isKing := p.(*pieces.King); isKing && k.Color() == c {
+			return k
+		}
+	}
+	return nil
+}
+
+func pawnStartingRank(c pieces.PieceColor) int {
+	if c == pieces.WHITE {
+		return setup.WhiteSecondRank
+	}
+	return setup.BlackSecondRank
+}
+
+func castlingRookCorner(queenside bool, c pieces.PieceColor) location.Location {
+	loc := location.Location{Row: setup.WhiteFirstRank, Col: setup.KingsideRookColumn}
+	if c == pieces.BLACK {
+		loc.Row = setup.BlackFirstRank
+	}
+	if queenside {
+		loc.Col = setup.QueensideRookColumn
+	}
+	return loc
+}
+
+func castlingSymbol(queenside bool, c pieces.PieceColor) byte {
+	symbol := byte('K')
+	if queenside {
+		symbol = 'Q'
+	}
+	if c == pieces.BLACK {
+		symbol += 'a' - 'A'
+	}
+	return symbol
+}
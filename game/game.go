@@ -0,0 +1,347 @@
+package game
+
+import (
+	"chess/board"
+	"chess/board/bitboard"
+	"chess/board/location"
+	"chess/pieces"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNoPiece is returned when there is no piece at the location a move starts from
+	ErrNoPiece = errors.New("no piece at location")
+	// ErrNotYourTurn is returned when the piece being moved does not belong to the side to move
+	ErrNotYourTurn = errors.New("not your turn")
+	// ErrIllegalMove is returned when the piece cannot legally move to the requested location
+	ErrIllegalMove = errors.New("illegal move")
+	// ErrPromotionRequired is returned when a pawn reaches the last rank without a piece to promote to
+	ErrPromotionRequired = errors.New("promotion piece required")
+	// ErrGameOver is returned when a move is requested after the game has ended
+	ErrGameOver = errors.New("game is over")
+)
+
+// MoveError describes why a requested move was rejected
+type MoveError struct {
+	From location.Location
+	To   location.Location
+	Err  error
+}
+
+func (e *MoveError) Error() string {
+	return fmt.Sprintf("move %v -> %v: %v", e.From, e.To, e.Err)
+}
+
+// Unwrap returns the underlying error so that errors.Is can match against ErrNoPiece, ErrNotYourTurn and ErrIllegalMove
+func (e *MoveError) Unwrap() error {
+	return e.Err
+}
+
+// Game represents a game of chess
+type Game struct {
+	board *board.Board
+	turn  pieces.PieceColor
+
+	// enPassant is the location a pawn passed over with a double push on the previous move, or nil if there is none
+	enPassant *location.Location
+
+	// halfmoveClock counts the moves since the last capture or pawn move, and fullmoveNumber counts the moves of
+	// both sides, starting at 1 and increasing after each black move
+	halfmoveClock  int
+	fullmoveNumber int
+
+	result      Result
+	termination Termination
+
+	// history holds the moves that have been made, and redo the moves that have been taken back since
+	history []historyEntry
+	redo    []redoEntry
+
+	// initialFEN is the FEN of the position the game started from
+	initialFEN string
+
+	// position mirrors the board as bitboards, and is kept in step with every move to maintain the position's hash
+	position *bitboard.Position
+}
+
+// NewGame returns a new game played on a given board with a given set of pieces placed on it, with white to move
+func NewGame(b *board.Board, pcs []pieces.Piece) *Game {
+	for _, p := range pcs {
+		b.PlacePiece(p)
+	}
+	g := &Game{
+		board:          b,
+		turn:           pieces.WHITE,
+		fullmoveNumber: 1,
+	}
+	g.position = bitboard.FromPieces(g.Pieces(), g.turn, g.enPassant)
+	g.updateResult()
+	g.initialFEN = g.FEN()
+	return g
+}
+
+// Clone returns a copy of the game with its own board, pieces and history, so that moves made or taken back in
+// either game do not affect the other
+func (g *Game) Clone() *Game {
+	clone := *g
+	clone.board = g.board.Clone()
+
+	// moves in the history refer to pieces that may no longer be on the board, such as captured pieces and promoted
+	// pawns, so every piece is cloned once and shared between the board and the moves that refer to it
+	clones := map[pieces.Piece]pieces.Piece{}
+	for _, p := range g.Pieces() {
+		clones[p] = clone.board.PieceAt(p.Location())
+	}
+	cloneOf := func(p pieces.Piece) pieces.Piece {
+		if p == nil {
+			return nil
+		}
+		if c, ok := clones[p]; ok {
+			return c
+		}
+		c := p.Clone()
+		clones[p] = c
+		return c
+	}
+	cloneMove := func(m pieces.Move) pieces.Move {
+		m.Piece = cloneOf(m.Piece)
+		m.Captured = cloneOf(m.Captured)
+		return m
+	}
+
+	clone.history = make([]historyEntry, len(g.history))
+	for i, entry := range g.history {
+		entry.move = cloneMove(entry.move)
+		entry.enPassant = cloneLocation(entry.enPassant)
+		clone.history[i] = entry
+	}
+	clone.redo = make([]redoEntry, len(g.redo))
+	for i, entry := range g.redo {
+		entry.move = cloneMove(entry.move)
+		clone.redo[i] = entry
+	}
+
+	clone.enPassant = cloneLocation(g.enPassant)
+	position := *g.position
+	clone.position = &position
+	return &clone
+}
+
+func cloneLocation(loc *location.Location) *location.Location {
+	if loc == nil {
+		return nil
+	}
+	clone := *loc
+	return &clone
+}
+
+// Board returns the board the game is played on
+func (g *Game) Board() *board.Board {
+	return g.board
+}
+
+// Pieces returns the pieces that are currently on the board
+func (g *Game) Pieces() []pieces.Piece {
+	return g.board.Pieces()
+}
+
+// Turn returns the color of the side to move
+func (g *Game) Turn() pieces.PieceColor {
+	return g.turn
+}
+
+// EnPassantTarget returns the location a pawn may capture onto en passant, and whether there is one
+func (g *Game) EnPassantTarget() (location.Location, bool) {
+	if g.enPassant == nil {
+		return location.Location{}, false
+	}
+	return *g.enPassant, true
+}
+
+// InitialFEN returns the FEN of the position the game started from
+func (g *Game) InitialFEN() string {
+	return g.initialFEN
+}
+
+// HalfmoveClock returns the number of moves made since the last capture or pawn move
+func (g *Game) HalfmoveClock() int {
+	return g.halfmoveClock
+}
+
+// FullmoveNumber returns the number of the current move, which starts at 1 and increases after each black move
+func (g *Game) FullmoveNumber() int {
+	return g.fullmoveNumber
+}
+
+// Hash returns the Zobrist hash of the current position, which is the same for positions with the same placement,
+// side to move, castling rights and en passant file
+func (g *Game) Hash() uint64 {
+	return g.position.Hash()
+}
+
+// Result returns the result of the game, which is InProgress until the game ends
+func (g *Game) Result() Result {
+	return g.result
+}
+
+// Termination returns the reason the game ended, or NoTermination if it is still in progress
+func (g *Game) Termination() Termination {
+	return g.termination
+}
+
+// IsOver returns whether the game has ended
+func (g *Game) IsOver() bool {
+	return g.result != InProgress
+}
+
+// InCheck returns whether the side to move is in check
+func (g *Game) InCheck() bool {
+	return pieces.InCheck(g.turn, g.Pieces())
+}
+
+// PieceAt returns the piece at a given location, or nil if the location is vacant
+func (g *Game) PieceAt(loc location.Location) pieces.Piece {
+	return g.board.PieceAt(loc)
+}
+
+// LegalMoves returns all of the legal moves for the side to move
+func (g *Game) LegalMoves() []pieces.Move {
+	return pieces.GenerateMoves(g.turn, g.Pieces(), g.enPassant)
+}
+
+// Move moves the piece at from to to, capturing any opponent piece there, and passes the turn to the other side.
+// Moves that promote a pawn must be made with MakeMove
+func (g *Game) Move(from, to location.Location) error {
+	return g.MakeMove(pieces.Move{From: from, To: to})
+}
+
+// MakeMove makes a move for the side to move, capturing any opponent piece and promoting pawns that reach the last
+// rank, and passes the turn to the other side. Only the From, To and Promotion fields of the move need to be set
+func (g *Game) MakeMove(m pieces.Move) error {
+	move, err := g.resolveMove(m)
+	if err != nil {
+		return err
+	}
+	g.apply(move)
+	g.redo = nil
+	return nil
+}
+
+// resolveMove returns the legal move matching a requested move
+func (g *Game) resolveMove(m pieces.Move) (pieces.Move, error) {
+	from, to := m.From, m.To
+	if g.IsOver() {
+		return pieces.Move{}, &MoveError{From: from, To: to, Err: ErrGameOver}
+	}
+	if !from.IsValid(board.DEFAULT_BOARD_SIZE) || !to.IsValid(board.DEFAULT_BOARD_SIZE) {
+		return pieces.Move{}, &MoveError{From: from, To: to, Err: ErrIllegalMove}
+	}
+
+	p := g.PieceAt(from)
+	if p == nil {
+		return pieces.Move{}, &MoveError{From: from, To: to, Err: ErrNoPiece}
+	}
+	if p.Color() != g.turn {
+		return pieces.Move{}, &MoveError{From: from, To: to, Err: ErrNotYourTurn}
+	}
+
+	for _, move := range pieces.PieceMoves(p, g.Pieces(), g.enPassant) {
+		if move.Matches(m) {
+			return move, nil
+		}
+		if move.To.Equals(to) && m.Promotion == pieces.NoPromotion {
+			return pieces.Move{}, &MoveError{From: from, To: to, Err: ErrPromotionRequired}
+		}
+	}
+	return pieces.Move{}, &MoveError{From: from, To: to, Err: ErrIllegalMove}
+}
+
+// apply makes a legal move, records it in the history and passes the turn to the other side
+func (g *Game) apply(m pieces.Move) {
+	entry := historyEntry{
+		move:           m,
+		hadMoved:       m.Piece.HasMoved(),
+		enPassant:      g.enPassant,
+		halfmoveClock:  g.halfmoveClock,
+		fullmoveNumber: g.fullmoveNumber,
+		result:         g.result,
+		termination:    g.termination,
+		hash:           g.position.Hash(),
+	}
+	entry.undo = g.position.MakeMove(bitboard.MoveOf(m))
+
+	// remove the captured piece, if any
+	if m.Captured != nil {
+		g.board.RemovePiece(m.Captured.Location())
+	}
+
+	if m.IsCastle() {
+		// castling moves the rook along with the king
+		g.board.RemovePiece(m.From)
+		rook := g.board.RemovePiece(castlingRookLocation(m))
+		m.Piece.(*pieces.King).Castle(m.To, []pieces.Piece{rook})
+		g.board.PlacePiece(m.Piece)
+		g.board.PlacePiece(rook)
+	} else {
+		g.board.MovePiece(m.From, m.To)
+	}
+
+	// replace a promoted pawn with its new piece
+	if m.Promotion != pieces.NoPromotion {
+		promoted := m.Promotion.NewPiece(m.To, m.Piece.Color())
+		// the promoted piece counts as having moved
+		promoted.Move(m.To)
+		g.board.PlacePiece(promoted)
+	}
+
+	// a pawn double push allows the opponent to capture en passant on the next move
+	g.enPassant = nil
+	if m.Is(pieces.DoublePush) {
+		g.enPassant = &location.Location{Row: (m.From.GetRow() + m.To.GetRow()) / 2, Col: m.From.GetCol()}
+	}
+
+	// captures and pawn moves reset the halfmove clock
+	if m.IsCapture() || m.Piece.Kind() == pieces.PawnKind {
+		g.halfmoveClock = 0
+	} else {
+		g.halfmoveClock++
+	}
+	if g.turn == pieces.BLACK {
+		g.fullmoveNumber++
+	}
+
+	g.history = append(g.history, entry)
+	g.turn = g.turn.Opponent()
+	g.updateResult()
+}
+
+// updateResult ends the game if the side to move has been checkmated or stalemated, neither side can checkmate, the
+// position has occurred five times or seventy-five moves have passed without a capture or pawn move
+func (g *Game) updateResult() {
+	if pieces.HasLegalMoves(g.turn, g.Pieces(), g.enPassant) {
+		switch {
+		case g.HasInsufficientMaterial():
+			g.termination = InsufficientMaterial
+			g.result = Draw
+		case g.Repetitions() >= 5:
+			g.termination = FivefoldRepetition
+			g.result = Draw
+		case g.halfmoveClock >= seventyFiveMoveHalfmoves:
+			g.termination = SeventyFiveMoveRule
+			g.result = Draw
+		}
+		return
+	}
+	if pieces.InCheck(g.turn, g.Pieces()) {
+		g.termination = Checkmate
+		if g.turn == pieces.WHITE {
+			g.result = BlackWins
+		} else {
+			g.result = WhiteWins
+		}
+	} else {
+		g.termination = Stalemate
+		g.result = Draw
+	}
+}
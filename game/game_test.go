@@ -0,0 +1,1055 @@
+package game
+
+import (
+	"chess/board"
+	"chess/board/location"
+	"chess/pieces"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMoveAlternatesTurns(t *testing.T) {
+	pcs := []pieces.Piece{
+		pieces.NewPawn(location.Location{Row: 1, Col: 4}, pieces.WHITE),
+		pieces.NewPawn(location.Location{Row: 6, Col: 4}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	if err := g.Move(location.Location{Row: 1, Col: 4}, location.Location{Row: 3, Col: 4}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Turn() != pieces.BLACK {
+		t.Errorf("expected black to move, got %v", g.Turn())
+	}
+	if err := g.Move(location.Location{Row: 6, Col: 4}, location.Location{Row: 4, Col: 4}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Turn() != pieces.WHITE {
+		t.Errorf("expected white to move, got %v", g.Turn())
+	}
+}
+
+func TestMoveErrors(t *testing.T) {
+	pcs := []pieces.Piece{
+		pieces.NewRook(location.Location{Row: 0, Col: 0}, pieces.WHITE),
+		pieces.NewRook(location.Location{Row: 7, Col: 7}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	err := g.Move(location.Location{Row: 3, Col: 3}, location.Location{Row: 4, Col: 3})
+	if !errors.Is(err, ErrNoPiece) {
+		t.Errorf("expected ErrNoPiece, got %v", err)
+	}
+	err = g.Move(location.Location{Row: 7, Col: 7}, location.Location{Row: 6, Col: 7})
+	if !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("expected ErrNotYourTurn, got %v", err)
+	}
+	err = g.Move(location.Location{Row: 0, Col: 0}, location.Location{Row: 1, Col: 1})
+	if !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("expected ErrIllegalMove, got %v", err)
+	}
+	err = g.Move(location.Location{Row: -1, Col: 0}, location.Location{Row: 1, Col: 0})
+	if !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("expected ErrIllegalMove moving from off the board, got %v", err)
+	}
+	if g.PieceAt(location.Location{Row: 8, Col: 0}) != nil {
+		t.Errorf("expected no piece off the board")
+	}
+	if g.Turn() != pieces.WHITE {
+		t.Errorf("rejected moves should not pass the turn")
+	}
+}
+
+func TestMoveCapture(t *testing.T) {
+	rook := pieces.NewRook(location.Location{Row: 0, Col: 0}, pieces.WHITE)
+	pcs := []pieces.Piece{
+		rook,
+		pieces.NewKnight(location.Location{Row: 5, Col: 0}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	if err := g.Move(location.Location{Row: 0, Col: 0}, location.Location{Row: 5, Col: 0}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(g.Pieces()) != 1 {
+		t.Fatalf("expected captured piece to be removed, %d pieces remain", len(g.Pieces()))
+	}
+	if g.PieceAt(location.Location{Row: 5, Col: 0}) != rook {
+		t.Errorf("expected rook at capture location")
+	}
+}
+
+func TestMoveIntoCheckIsIllegal(t *testing.T) {
+	pcs := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE),
+		pieces.NewKnight(location.Location{Row: 1, Col: 4}, pieces.WHITE),
+		pieces.NewRook(location.Location{Row: 7, Col: 4}, pieces.BLACK),
+		pieces.NewKing(location.Location{Row: 7, Col: 0}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	err := g.Move(location.Location{Row: 1, Col: 4}, location.Location{Row: 3, Col: 5})
+	if !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("expected pinned knight move to be illegal, got %v", err)
+	}
+}
+
+func TestCheckmate(t *testing.T) {
+	pcs := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE),
+		pieces.NewRook(location.Location{Row: 0, Col: 0}, pieces.WHITE),
+		pieces.NewKing(location.Location{Row: 7, Col: 7}, pieces.BLACK),
+		pieces.NewPawn(location.Location{Row: 6, Col: 6}, pieces.BLACK),
+		pieces.NewPawn(location.Location{Row: 6, Col: 7}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	if err := g.Move(location.Location{Row: 0, Col: 0}, location.Location{Row: 7, Col: 0}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Result() != WhiteWins || g.Termination() != Checkmate {
+		t.Errorf("expected %v by %v, got %v by %v", WhiteWins, Checkmate, g.Result(), g.Termination())
+	}
+	err := g.Move(location.Location{Row: 6, Col: 6}, location.Location{Row: 5, Col: 6})
+	if !errors.Is(err, ErrGameOver) {
+		t.Errorf("expected ErrGameOver, got %v", err)
+	}
+}
+
+func TestStalemate(t *testing.T) {
+	pcs := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 4, Col: 2}, pieces.WHITE),
+		pieces.NewQueen(location.Location{Row: 3, Col: 7}, pieces.WHITE),
+		pieces.NewKing(location.Location{Row: 4, Col: 0}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	if g.IsOver() {
+		t.Fatalf("game should be in progress")
+	}
+	if err := g.Move(location.Location{Row: 3, Col: 7}, location.Location{Row: 3, Col: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Result() != Draw || g.Termination() != Stalemate {
+		t.Errorf("expected %v by %v, got %v by %v", Draw, Stalemate, g.Result(), g.Termination())
+	}
+}
+
+func TestEnPassant(t *testing.T) {
+	whitePawn := pieces.NewPawn(location.Location{Row: 3, Col: 4}, pieces.WHITE)
+	whitePawn.Move(location.Location{Row: 4, Col: 4})
+	pcs := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE),
+		whitePawn,
+		pieces.NewKing(location.Location{Row: 7, Col: 0}, pieces.BLACK),
+		pieces.NewPawn(location.Location{Row: 6, Col: 3}, pieces.BLACK),
+		pieces.NewPawn(location.Location{Row: 6, Col: 7}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	// white waits with the king so black can double push
+	if err := g.Move(location.Location{Row: 0, Col: 4}, location.Location{Row: 0, Col: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := g.Move(location.Location{Row: 6, Col: 3}, location.Location{Row: 4, Col: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	target, ok := g.EnPassantTarget()
+	if !ok || !target.Equals(location.Location{Row: 5, Col: 3}) {
+		t.Fatalf("expected en passant target {5 3}, got %v %v", target, ok)
+	}
+	if err := g.Move(location.Location{Row: 4, Col: 4}, location.Location{Row: 5, Col: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.PieceAt(location.Location{Row: 4, Col: 3}) != nil {
+		t.Errorf("expected bypassed pawn to be captured")
+	}
+	if len(g.Pieces()) != 4 {
+		t.Errorf("expected 4 pieces after en passant, got %d", len(g.Pieces()))
+	}
+}
+
+func TestEnPassantExpires(t *testing.T) {
+	whitePawn := pieces.NewPawn(location.Location{Row: 3, Col: 4}, pieces.WHITE)
+	whitePawn.Move(location.Location{Row: 4, Col: 4})
+	pcs := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE),
+		whitePawn,
+		pieces.NewKing(location.Location{Row: 7, Col: 0}, pieces.BLACK),
+		pieces.NewPawn(location.Location{Row: 6, Col: 5}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	moves := [][2]location.Location{
+		{{Row: 0, Col: 4}, {Row: 0, Col: 3}},
+		{{Row: 6, Col: 5}, {Row: 4, Col: 5}},
+		{{Row: 0, Col: 3}, {Row: 0, Col: 4}},
+		{{Row: 7, Col: 0}, {Row: 7, Col: 1}},
+	}
+	for _, m := range moves {
+		if err := g.Move(m[0], m[1]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	err := g.Move(location.Location{Row: 4, Col: 4}, location.Location{Row: 5, Col: 5})
+	if !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("expected en passant to expire after one move, got %v", err)
+	}
+}
+
+func TestPromotion(t *testing.T) {
+	pcs := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE),
+		pieces.NewPawn(location.Location{Row: 6, Col: 0}, pieces.WHITE),
+		pieces.NewKing(location.Location{Row: 7, Col: 7}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	from := location.Location{Row: 6, Col: 0}
+	to := location.Location{Row: 7, Col: 0}
+
+	err := g.Move(from, to)
+	if !errors.Is(err, ErrPromotionRequired) {
+		t.Errorf("expected ErrPromotionRequired, got %v", err)
+	}
+	if err := g.MakeMove(pieces.Move{From: from, To: to, Promotion: pieces.PromoteToKnight}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	knight, ok := g.PieceAt(to).(*pieces.Knight)
+	if !ok || knight.Color() != pieces.WHITE {
+		t.Errorf("expected white knight at %v, got %v", to, g.PieceAt(to))
+	}
+	if len(g.Pieces()) != 3 {
+		t.Errorf("expected pawn to be replaced, got %d pieces", len(g.Pieces()))
+	}
+}
+
+func TestPromotionOnNonPromotingMove(t *testing.T) {
+	pcs := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE),
+		pieces.NewPawn(location.Location{Row: 1, Col: 0}, pieces.WHITE),
+		pieces.NewKing(location.Location{Row: 7, Col: 7}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	m := pieces.Move{From: location.Location{Row: 1, Col: 0}, To: location.Location{Row: 2, Col: 0}, Promotion: pieces.PromoteToQueen}
+	if err := g.MakeMove(m); !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("expected ErrIllegalMove, got %v", err)
+	}
+}
+
+func TestCastle(t *testing.T) {
+	king := pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE)
+	rook := pieces.NewRook(location.Location{Row: 0, Col: 7}, pieces.WHITE)
+	pcs := []pieces.Piece{
+		king,
+		rook,
+		pieces.NewKing(location.Location{Row: 7, Col: 4}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	if err := g.Move(location.Location{Row: 0, Col: 4}, location.Location{Row: 0, Col: 6}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !king.Location().Equals(location.Location{Row: 0, Col: 6}) {
+		t.Errorf("expected king on {0 6}, got %v", king.Location())
+	}
+	if !rook.Location().Equals(location.Location{Row: 0, Col: 5}) {
+		t.Errorf("expected rook on {0 5}, got %v", rook.Location())
+	}
+}
+
+type pieceState struct {
+	piece    pieces.Piece
+	loc      location.Location
+	hasMoved bool
+}
+
+func snapshot(g *Game) []pieceState {
+	var states []pieceState
+	for _, p := range g.Pieces() {
+		states = append(states, pieceState{piece: p, loc: p.Location(), hasMoved: p.HasMoved()})
+	}
+	return states
+}
+
+func compareSnapshot(g *Game, expected []pieceState, t *testing.T) {
+	t.Helper()
+
+	if len(g.Pieces()) != len(expected) {
+		t.Fatalf("expected %d pieces, got %d", len(expected), len(g.Pieces()))
+	}
+	for _, s := range expected {
+		if g.PieceAt(s.loc) != s.piece {
+			t.Errorf("expected %T at %v", s.piece, s.loc)
+		}
+		if s.piece.HasMoved() != s.hasMoved {
+			t.Errorf("expected %T at %v to have hasMoved %v", s.piece, s.loc, s.hasMoved)
+		}
+	}
+}
+
+func TestUndoRestoresPosition(t *testing.T) {
+	whitePawn := pieces.NewPawn(location.Location{Row: 3, Col: 4}, pieces.WHITE)
+	whitePawn.Move(location.Location{Row: 4, Col: 4})
+	pcs := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE),
+		pieces.NewRook(location.Location{Row: 0, Col: 0}, pieces.WHITE),
+		pieces.NewPawn(location.Location{Row: 6, Col: 1}, pieces.WHITE),
+		whitePawn,
+		pieces.NewKing(location.Location{Row: 7, Col: 4}, pieces.BLACK),
+		pieces.NewKnight(location.Location{Row: 7, Col: 0}, pieces.BLACK),
+		pieces.NewPawn(location.Location{Row: 6, Col: 3}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	initial := snapshot(g)
+	moves := []pieces.Move{
+		// castle queenside
+		{From: location.Location{Row: 0, Col: 4}, To: location.Location{Row: 0, Col: 2}},
+		{From: location.Location{Row: 6, Col: 3}, To: location.Location{Row: 4, Col: 3}},
+		// capture en passant
+		{From: location.Location{Row: 4, Col: 4}, To: location.Location{Row: 5, Col: 3}},
+		{From: location.Location{Row: 7, Col: 4}, To: location.Location{Row: 7, Col: 5}},
+		// capture the knight and promote
+		{From: location.Location{Row: 6, Col: 1}, To: location.Location{Row: 7, Col: 0}, Promotion: pieces.PromoteToQueen},
+	}
+	for _, m := range moves {
+		if err := g.MakeMove(m); err != nil {
+			t.Fatalf("unexpected error making %v: %v", m, err)
+		}
+	}
+	final := snapshot(g)
+
+	for g.CanUndo() {
+		if err := g.Undo(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	compareSnapshot(g, initial, t)
+	if g.Turn() != pieces.WHITE {
+		t.Errorf("expected white to move after undoing every move")
+	}
+	if _, ok := g.EnPassantTarget(); ok {
+		t.Errorf("expected no en passant target after undoing every move")
+	}
+
+	for g.CanRedo() {
+		if err := g.Redo(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(g.History()) != len(moves) {
+		t.Errorf("expected %d moves in history, got %d", len(moves), len(g.History()))
+	}
+	// the promoted queen is a new piece each time the move is made
+	for _, s := range final {
+		if _, isQueen := s.piece.(*pieces.Queen); isQueen {
+			if _, ok := g.PieceAt(s.loc).(*pieces.Queen); !ok {
+				t.Errorf("expected a queen at %v after redo", s.loc)
+			}
+			continue
+		}
+		if g.PieceAt(s.loc) != s.piece {
+			t.Errorf("expected %T at %v after redo", s.piece, s.loc)
+		}
+	}
+}
+
+func TestUndoRedoErrors(t *testing.T) {
+	// the pawn keeps the game from being drawn by insufficient material
+	pcs := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE),
+		pieces.NewPawn(location.Location{Row: 1, Col: 0}, pieces.WHITE),
+		pieces.NewKing(location.Location{Row: 7, Col: 4}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+
+	if err := g.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("expected ErrNothingToUndo, got %v", err)
+	}
+	if err := g.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Errorf("expected ErrNothingToRedo, got %v", err)
+	}
+
+	if err := g.Move(location.Location{Row: 0, Col: 4}, location.Location{Row: 0, Col: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := g.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// a new move discards the moves that could have been redone
+	if err := g.Move(location.Location{Row: 0, Col: 4}, location.Location{Row: 0, Col: 5}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.CanRedo() {
+		t.Errorf("expected redo stack to be cleared by a new move")
+	}
+}
+
+func TestStandardPosition(t *testing.T) {
+	b, pcs := StandardPosition()
+
+	if len(pcs) != 32 || len(b.Pieces()) != 32 {
+		t.Fatalf("expected 32 pieces, got %d in list and %d on board", len(pcs), len(b.Pieces()))
+	}
+	for _, p := range pcs {
+		if b.PieceAt(p.Location()) != p {
+			t.Errorf("expected %T at %v on the board", p, p.Location())
+		}
+	}
+	if _, ok := b.PieceAt(location.Location{Row: 0, Col: 4}).(*pieces.King); !ok {
+		t.Errorf("expected white king on {0 4}")
+	}
+	if _, ok := b.PieceAt(location.Location{Row: 7, Col: 3}).(*pieces.Queen); !ok {
+		t.Errorf("expected black queen on {7 3}")
+	}
+
+	g := NewStandardGame()
+	if len(g.LegalMoves()) != 20 {
+		t.Errorf("expected 20 legal moves from the starting position, got %d", len(g.LegalMoves()))
+	}
+}
+
+func TestFENRoundTrip(t *testing.T) {
+	fens := []string{
+		StartingFEN,
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		"r3k2r/8/8/8/8/8/8/R3K2R b Kq - 12 40",
+	}
+	for _, fen := range fens {
+		g, err := NewGameFromFEN(fen)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", fen, err)
+			continue
+		}
+		if g.FEN() != fen {
+			t.Errorf("expected %q, got %q", fen, g.FEN())
+		}
+	}
+}
+
+func TestFENAfterMoves(t *testing.T) {
+	g := NewStandardGame()
+	if g.FEN() != StartingFEN {
+		t.Errorf("expected %q, got %q", StartingFEN, g.FEN())
+	}
+
+	moves := [][2]location.Location{
+		{{Row: 1, Col: 4}, {Row: 3, Col: 4}},
+		{{Row: 7, Col: 6}, {Row: 5, Col: 5}},
+		{{Row: 0, Col: 4}, {Row: 1, Col: 4}},
+	}
+	expected := []string{
+		"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+		"rnbqkb1r/pppppppp/5n2/8/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 1 2",
+		"rnbqkb1r/pppppppp/5n2/8/4P3/8/PPPPKPPP/RNBQ1BNR b kq - 2 2",
+	}
+	for i, m := range moves {
+		if err := g.Move(m[0], m[1]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if g.FEN() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], g.FEN())
+		}
+	}
+
+	if err := g.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.FEN() != expected[1] {
+		t.Errorf("expected %q after undo, got %q", expected[1], g.FEN())
+	}
+}
+
+func TestHash(t *testing.T) {
+	g := NewStandardGame()
+	start := g.Hash()
+
+	// the knights return home, reaching the starting position again
+	for _, san := range []string{"Nf3", "Nf6", "Ng1", "Ng8"} {
+		if err := g.MoveSAN(san); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if g.Hash() != start {
+		t.Errorf("expected the starting hash %x after the knights return, got %x", start, g.Hash())
+	}
+
+	// the same position reached by a different move order hashes the same
+	a, b := NewStandardGame(), NewStandardGame()
+	for _, san := range []string{"e4", "e5", "Nf3", "Nc6"} {
+		if err := a.MoveSAN(san); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	for _, san := range []string{"Nf3", "Nc6", "e4", "e5"} {
+		if err := b.MoveSAN(san); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if a.Hash() != b.Hash() {
+		t.Errorf("expected transposed positions to hash the same, got %x and %x", a.Hash(), b.Hash())
+	}
+
+	// the same placement without castling rights hashes differently
+	fromFEN, err := NewGameFromFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w - - 0 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fromFEN.Hash() == start {
+		t.Error("expected the hash to depend on castling rights")
+	}
+
+	if err := a.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c := NewStandardGame()
+	for _, san := range []string{"e4", "e5"} {
+		if err := c.MoveSAN(san); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if a.Hash() != c.Hash() {
+		t.Errorf("expected undo to restore the hash %x, got %x", c.Hash(), a.Hash())
+	}
+}
+
+func TestFENErrors(t *testing.T) {
+	fens := []string{
+		"",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/9/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNX w KQkq - 0 1",
+		"rnbq1bnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQ - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR x KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkqK - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/1NBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e3 0 1",
+		"4k3/8/8/8/3p4/8/8/4K3 b - e3 0 1",
+		"4k3/8/8/8/4P3/4N3/8/4K3 b - e3 0 1",
+		"4k3/8/8/8/4P3/8/4N3/4K3 b - e3 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - -1 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 0",
+		"rnbqkbnP/pppppppp/8/8/8/8/PPPPPPP1/RNBQKBNR w KQkq - 0 1",
+		"4k3/8/8/8/8/8/8/3K3R w K - 0 1",
+	}
+	for _, fen := range fens {
+		if _, err := NewGameFromFEN(fen); !errors.Is(err, ErrInvalidFEN) {
+			t.Errorf("expected ErrInvalidFEN parsing %q, got %v", fen, err)
+		}
+	}
+}
+
+const operaGame = `[Event "Paris"]
+[Site "Paris FRA"]
+[Date "1858.??.??"]
+[Round "?"]
+[White "Paul Morphy"]
+[Black "Duke Karl / Count Isouard"]
+[Result "1-0"]
+
+1. e4 e5 2. Nf3 d6 3. d4 Bg4 {This is a weak move already.} 4. dxe5 Bxf3 5. Qxf3
+dxe5 6. Bc4 Nf6 7. Qb3 Qe7 8. Nc3 (8. Qxb7 Qb4+ 9. Qxb4 Bxb4+) c6 9. Bg5 b5 10.
+Nxb5 cxb5 11. Bxb5+ Nbd7 12. O-O-O Rd8 13. Rxd7 Rxd7 14. Rd1 Qe6 15. Bxd7+ Nxd7
+16. Qb8+ Nxb8 17. Rd8# 1-0
+`
+
+func TestReadPGN(t *testing.T) {
+	records, err := ReadPGN(strings.NewReader(operaGame + "\n" + "1. f3 e5 2. g4 Qh4# 0-1\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 games, got %d", len(records))
+	}
+
+	opera := records[0]
+	if opera.Tags["White"] != "Paul Morphy" {
+		t.Errorf("expected White tag to be read, got %q", opera.Tags["White"])
+	}
+	if len(opera.Game.History()) != 33 {
+		t.Errorf("expected 33 moves, got %d", len(opera.Game.History()))
+	}
+	if opera.Game.Result() != WhiteWins || opera.Game.Termination() != Checkmate {
+		t.Errorf("expected white to win by checkmate, got %v by %v", opera.Game.Result(), opera.Game.Termination())
+	}
+	expectedFEN := "1n1Rkb1r/p4ppp/4q3/4p1B1/4P3/8/PPP2PPP/2K5 b k - 1 17"
+	if opera.Game.FEN() != expectedFEN {
+		t.Errorf("expected %q, got %q", expectedFEN, opera.Game.FEN())
+	}
+
+	if records[1].Game.Result() != BlackWins {
+		t.Errorf("expected black to win, got %v", records[1].Game.Result())
+	}
+}
+
+func TestWritePGNRoundTrip(t *testing.T) {
+	records, err := ReadPGN(strings.NewReader(operaGame))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sb strings.Builder
+	if err := WritePGN(&sb, records[0].Game, records[0].Tags); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	written := sb.String()
+	if !strings.HasPrefix(written, "[Event \"Paris\"]\n[Site \"Paris FRA\"]\n") {
+		t.Errorf("expected seven tag roster first, got:\n%s", written)
+	}
+	for _, san := range []string{"12. O-O-O", "Nbd7", "Bxb5+", "17. Rd8# 1-0"} {
+		if !strings.Contains(written, san) {
+			t.Errorf("expected %q in movetext, got:\n%s", san, written)
+		}
+	}
+
+	reread, err := ReadPGN(strings.NewReader(written))
+	if err != nil {
+		t.Fatalf("unexpected error reading written PGN: %v", err)
+	}
+	if reread[0].Game.FEN() != records[0].Game.FEN() {
+		t.Errorf("expected written game to replay to %q, got %q", records[0].Game.FEN(), reread[0].Game.FEN())
+	}
+}
+
+func TestWritePGNFromPosition(t *testing.T) {
+	g, err := NewGameFromFEN("4k3/8/8/8/8/8/8/R3K3 b Q - 0 10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := g.Move(location.Location{Row: 7, Col: 4}, location.Location{Row: 7, Col: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sb strings.Builder
+	if err := WritePGN(&sb, g, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, s := range []string{"[Result \"*\"]", "[SetUp \"1\"]", "[FEN \"4k3/8/8/8/8/8/8/R3K3 b Q - 0 10\"]", "10... Kd8 *"} {
+		if !strings.Contains(sb.String(), s) {
+			t.Errorf("expected %q in PGN, got:\n%s", s, sb.String())
+		}
+	}
+}
+
+func TestWritePGNWithoutKings(t *testing.T) {
+	// the starting position has no black king, so it cannot be read back from its FEN
+	pcs := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE),
+		pieces.NewRook(location.Location{Row: 0, Col: 0}, pieces.WHITE),
+		pieces.NewPawn(location.Location{Row: 6, Col: 7}, pieces.BLACK),
+	}
+	g := NewGame(board.NewBoard(), pcs)
+	if err := g.Move(location.Location{Row: 0, Col: 0}, location.Location{Row: 1, Col: 0}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fen := g.FEN()
+
+	var sb strings.Builder
+	if err := WritePGN(&sb, g, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(sb.String(), "1. Ra2 *") {
+		t.Errorf("expected %q in PGN, got:\n%s", "1. Ra2 *", sb.String())
+	}
+	if g.FEN() != fen || len(g.History()) != 1 {
+		t.Errorf("expected writing the game to leave it unchanged, got %q", g.FEN())
+	}
+}
+
+func TestReadPGNErrors(t *testing.T) {
+	tests := []struct {
+		pgn  string
+		line int
+		col  int
+		err  error
+	}{
+		{"1. e4 e5\n2. Ke3 *", 2, 4, ErrIllegalMove},
+		{"1. Nc3 Nc6 2. Nb5 Nb4 3. Nf3 Nf6 4. Nd4 *", 1, 37, ErrAmbiguousMove},
+		{"[Event \"x\"\n1. e4 *", 1, 1, ErrInvalidPGN},
+		{"1. e4 e5", 1, 9, ErrInvalidPGN},
+		{"[Result \"1-0\"]\n1. e4 0-1", 2, 7, ErrInvalidPGN},
+	}
+	for _, test := range tests {
+		_, err := ReadPGN(strings.NewReader(test.pgn))
+		var pgnErr *PGNError
+		if !errors.As(err, &pgnErr) {
+			t.Errorf("expected PGNError reading %q, got %v", test.pgn, err)
+			continue
+		}
+		if pgnErr.Line != test.line || pgnErr.Col != test.col {
+			t.Errorf("expected error at %d:%d reading %q, got %v", test.line, test.col, test.pgn, err)
+		}
+		if test.err != nil && !errors.Is(err, test.err) {
+			t.Errorf("expected %v reading %q, got %v", test.err, test.pgn, err)
+		}
+	}
+}
+
+func TestSANDisambiguation(t *testing.T) {
+	g, err := NewGameFromFEN("6k1/8/8/8/8/Q7/8/Q1Q4K w - - 0 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		from     location.Location
+		expected string
+	}{
+		{location.Location{Row: 0, Col: 0}, "Qa1b2"},
+		{location.Location{Row: 2, Col: 0}, "Q3b2"},
+		{location.Location{Row: 0, Col: 2}, "Qcb2"},
+	}
+	for _, test := range tests {
+		san, err := g.SAN(pieces.Move{From: test.from, To: location.Location{Row: 1, Col: 1}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if san != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, san)
+		}
+		m, err := g.ParseSAN(san)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", san, err)
+		}
+		if !m.From.Equals(test.from) {
+			t.Errorf("expected %q to move from %v, got %v", san, test.from, m.From)
+		}
+	}
+
+	if _, err := g.ParseSAN("Qb2"); !errors.Is(err, ErrAmbiguousMove) {
+		t.Errorf("expected ErrAmbiguousMove, got %v", err)
+	}
+	if _, err := g.ParseSAN("Qa1c3d4"); !errors.Is(err, ErrInvalidSAN) {
+		t.Errorf("expected ErrInvalidSAN, got %v", err)
+	}
+	if _, err := g.ParseSAN("Qh2"); !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("expected ErrIllegalMove, got %v", err)
+	}
+}
+
+func TestSANSpecialMoves(t *testing.T) {
+	g, err := NewGameFromFEN("r3k3/1P6/8/3pP3/8/8/8/R3K2R w KQq d6 0 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		move     pieces.Move
+		expected string
+	}{
+		{pieces.Move{From: location.Location{Row: 0, Col: 4}, To: location.Location{Row: 0, Col: 6}}, "O-O"},
+		{pieces.Move{From: location.Location{Row: 0, Col: 4}, To: location.Location{Row: 0, Col: 2}}, "O-O-O"},
+		{pieces.Move{From: location.Location{Row: 4, Col: 4}, To: location.Location{Row: 5, Col: 3}}, "exd6"},
+		{pieces.Move{From: location.Location{Row: 6, Col: 1}, To: location.Location{Row: 7, Col: 0}, Promotion: pieces.PromoteToQueen}, "bxa8=Q+"},
+		{pieces.Move{From: location.Location{Row: 6, Col: 1}, To: location.Location{Row: 7, Col: 1}, Promotion: pieces.PromoteToKnight}, "b8=N"},
+		{pieces.Move{From: location.Location{Row: 0, Col: 0}, To: location.Location{Row: 7, Col: 0}}, "Rxa8+"},
+	}
+	for _, test := range tests {
+		san, err := g.SAN(test.move)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if san != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, san)
+		}
+		m, err := g.ParseSAN(san)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", san, err)
+		}
+		if !m.Matches(test.move) {
+			t.Errorf("expected %q to parse to %v, got %v", san, test.move, m)
+		}
+	}
+
+	if err := g.MoveSAN("exd6e.p."); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.PieceAt(location.Location{Row: 4, Col: 3}) != nil {
+		t.Errorf("expected en passant capture to remove the pawn on d5")
+	}
+}
+
+func TestPerft(t *testing.T) {
+	// published node counts for standard test positions, kept shallow as the pieces package is slow to search
+	tests := []struct {
+		fen   string
+		depth int
+		nodes uint64
+	}{
+		{StartingFEN, 3, 8902},
+		{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", 2, 2039},
+		{"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", 3, 2812},
+		{"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", 3, 9467},
+		{"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", 2, 1486},
+		{"r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", 2, 2079},
+	}
+	for _, test := range tests {
+		g, err := NewGameFromFEN(test.fen)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if nodes := g.Perft(test.depth); nodes != test.nodes {
+			t.Errorf("%s: depth %d: expected %d positions, got %d", test.fen, test.depth, test.nodes, nodes)
+		}
+		if g.FEN() != test.fen {
+			t.Errorf("expected the game to be left at %q, got %q", test.fen, g.FEN())
+		}
+
+		var total uint64
+		for _, result := range g.Divide(test.depth) {
+			total += result.Nodes
+		}
+		if total != test.nodes {
+			t.Errorf("%s: expected divide counts adding up to %d, got %d", test.fen, test.nodes, total)
+		}
+	}
+}
+
+func TestRepetition(t *testing.T) {
+	g := NewStandardGame()
+	if err := g.ClaimDraw(); !errors.Is(err, ErrNoDrawClaim) {
+		t.Errorf("expected ErrNoDrawClaim, got %v", err)
+	}
+
+	shuffle := []string{"Nf3", "Nf6", "Ng1", "Ng8"}
+	for i := 0; i < 2; i++ {
+		for _, san := range shuffle {
+			if err := g.MoveSAN(san); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+	if g.Repetitions() != 3 || !g.CanClaimThreefoldRepetition() {
+		t.Fatalf("expected a claimable threefold repetition, got %d repetitions", g.Repetitions())
+	}
+	if g.IsOver() {
+		t.Fatalf("a threefold repetition should not end the game until it is claimed")
+	}
+
+	claimed := NewStandardGame()
+	for i := 0; i < 2; i++ {
+		for _, san := range shuffle {
+			if err := claimed.MoveSAN(san); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+	if err := claimed.ClaimDraw(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claimed.Result() != Draw || claimed.Termination() != ThreefoldRepetition {
+		t.Errorf("expected %v by %v, got %v by %v", Draw, ThreefoldRepetition, claimed.Result(), claimed.Termination())
+	}
+
+	// taking back the last move reopens the game, and replaying it restores the claim
+	if err := claimed.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claimed.IsOver() {
+		t.Errorf("expected the game to be in progress after taking back the last move")
+	}
+	if err := claimed.Redo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claimed.Result() != Draw || claimed.Termination() != ThreefoldRepetition {
+		t.Errorf("expected %v by %v after redo, got %v by %v", Draw, ThreefoldRepetition, claimed.Result(),
+			claimed.Termination())
+	}
+
+	// a move taken back before the claim cannot be replayed into the finished game
+	undone := NewStandardGame()
+	for i := 0; i < 2; i++ {
+		for _, san := range shuffle {
+			if err := undone.MoveSAN(san); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+	if err := undone.MoveSAN("e4"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := undone.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := undone.ClaimDraw(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if undone.CanRedo() {
+		t.Errorf("expected no redo after a draw is claimed")
+	}
+	if err := undone.Redo(); !errors.Is(err, ErrGameOver) {
+		t.Errorf("expected ErrGameOver, got %v", err)
+	}
+	if undone.Termination() != ThreefoldRepetition || len(undone.History()) != 8 {
+		t.Errorf("expected the claimed draw to stand after 8 moves, got %v after %d", undone.Termination(),
+			len(undone.History()))
+	}
+
+	for i := 0; i < 2; i++ {
+		for _, san := range shuffle {
+			if err := g.MoveSAN(san); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+	if g.Result() != Draw || g.Termination() != FivefoldRepetition {
+		t.Errorf("expected %v by %v, got %v by %v", Draw, FivefoldRepetition, g.Result(), g.Termination())
+	}
+	if err := g.MoveSAN("e4"); !errors.Is(err, ErrGameOver) {
+		t.Errorf("expected ErrGameOver, got %v", err)
+	}
+
+	if err := g.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.IsOver() || g.Repetitions() != 4 {
+		t.Errorf("expected undo to reopen the game, got %v with %d repetitions", g.Result(), g.Repetitions())
+	}
+}
+
+func TestFiftyMoveRule(t *testing.T) {
+	g, err := NewGameFromFEN("4k3/8/8/8/8/8/8/R3K3 w - - 99 80")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.CanClaimFiftyMoveRule() {
+		t.Errorf("expected no claim after 99 halfmoves")
+	}
+	if err := g.MoveSAN("Ra2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !g.CanClaimFiftyMoveRule() {
+		t.Fatalf("expected a fifty-move claim after %d halfmoves", g.HalfmoveClock())
+	}
+	if err := g.ClaimDraw(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Result() != Draw || g.Termination() != FiftyMoveRule {
+		t.Errorf("expected %v by %v, got %v by %v", Draw, FiftyMoveRule, g.Result(), g.Termination())
+	}
+
+	g, err = NewGameFromFEN("4k3/8/8/8/8/8/8/R3K3 w - - 149 80")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := g.MoveSAN("Ra2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Result() != Draw || g.Termination() != SeventyFiveMoveRule {
+		t.Errorf("expected %v by %v, got %v by %v", Draw, SeventyFiveMoveRule, g.Result(), g.Termination())
+	}
+
+	// checkmate on the seventy-fifth move still wins
+	g, err = NewGameFromFEN("4k3/R7/4K3/8/8/8/8/8 w - - 149 80")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := g.MoveSAN("Ra8"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Result() != WhiteWins || g.Termination() != Checkmate {
+		t.Errorf("expected %v by %v, got %v by %v", WhiteWins, Checkmate, g.Result(), g.Termination())
+	}
+}
+
+func TestInsufficientMaterial(t *testing.T) {
+	tests := []struct {
+		fen          string
+		insufficient bool
+	}{
+		{"4k3/8/8/8/8/8/8/4K3 w - - 0 1", true},
+		{"4k3/8/8/8/8/8/8/2B1K3 w - - 0 1", true},
+		{"4k3/8/8/8/8/8/8/1N2K3 b - - 0 1", true},
+		// bishops on the same color tiles, c1 and f8 are both black
+		{"5bk1/8/8/8/8/8/8/2B1K3 w - - 0 1", true},
+		{"2b1k3/8/8/8/8/8/8/2B1K3 w - - 0 1", false},
+		{"4k3/8/8/8/8/8/8/1NB1K3 w - - 0 1", false},
+		{"1n2k3/8/8/8/8/8/8/1N2K3 w - - 0 1", false},
+		{"4k3/8/8/8/8/8/4P3/4K3 w - - 0 1", false},
+		{"4k3/8/8/8/8/8/8/R3K3 w - - 0 1", false},
+	}
+	for _, test := range tests {
+		g, err := NewGameFromFEN(test.fen)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if g.HasInsufficientMaterial() != test.insufficient {
+			t.Errorf("%s: expected insufficient material %v", test.fen, test.insufficient)
+		}
+		if test.insufficient && (g.Result() != Draw || g.Termination() != InsufficientMaterial) {
+			t.Errorf("%s: expected %v by %v, got %v by %v", test.fen, Draw, InsufficientMaterial, g.Result(), g.Termination())
+		}
+	}
+
+	// capturing the last pawn leaves only the kings
+	g, err := NewGameFromFEN("4k3/8/8/8/8/8/4p3/4K3 w - - 0 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := g.MoveSAN("Kxe2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Termination() != InsufficientMaterial {
+		t.Errorf("expected %v, got %v", InsufficientMaterial, g.Termination())
+	}
+}
+
+func TestClone(t *testing.T) {
+	g := NewStandardGame()
+	for _, san := range []string{"e4", "d5", "exd5", "Qxd5"} {
+		if err := g.MoveSAN(san); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := g.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fen := g.FEN()
+
+	clone := g.Clone()
+	if clone.FEN() != fen || clone.Hash() != g.Hash() {
+		t.Fatalf("expected clone at %q, got %q", fen, clone.FEN())
+	}
+	for _, p := range g.Pieces() {
+		if clone.PieceAt(p.Location()) == p {
+			t.Fatalf("expected clone to hold copies of the pieces, %v is shared", p.Location())
+		}
+	}
+
+	// moves in the clone, including taking back a capture made before it was cloned, leave the original alone
+	if err := clone.Redo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, san := range []string{"Nc3", "Qe5+"} {
+		if err := clone.MoveSAN(san); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	for clone.CanUndo() {
+		if err := clone.Undo(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if clone.FEN() != StartingFEN {
+		t.Errorf("expected clone to return to the start, got %q", clone.FEN())
+	}
+	if g.FEN() != fen || len(g.History()) != 3 || !g.CanRedo() {
+		t.Errorf("expected original to be unchanged at %q, got %q", fen, g.FEN())
+	}
+
+	if err := g.Redo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.FEN() != "rnb1kbnr/ppp1pppp/8/3q4/8/8/PPPP1PPP/RNBQKBNR w KQkq - 0 3" {
+		t.Errorf("unexpected position after redo in original: %q", g.FEN())
+	}
+}
@@ -0,0 +1,142 @@
+package game
+
+import (
+	"chess/board/bitboard"
+	"chess/board/location"
+	"chess/game/setup"
+	"chess/pieces"
+	"errors"
+)
+
+var (
+	// ErrNothingToUndo is returned when Undo is called before any move has been made
+	ErrNothingToUndo = errors.New("no move to undo")
+	// ErrNothingToRedo is returned when Redo is called without a move having been taken back
+	ErrNothingToRedo = errors.New("no move to redo")
+)
+
+// historyEntry records a move along with the state it replaced, so that the move can be taken back exactly
+type historyEntry struct {
+	move pieces.Move
+	// hadMoved is whether the moving piece had moved before this move
+	hadMoved bool
+
+	enPassant      *location.Location
+	halfmoveClock  int
+	fullmoveNumber int
+	result         Result
+	termination    Termination
+	// hash is the hash of the position before the move, and undo restores the bitboard mirror after it
+	hash uint64
+	undo bitboard.Undo
+}
+
+// redoEntry records a move taken back by Undo, along with the draw claimed after it, if any, so that Redo restores
+// the game exactly as it was
+type redoEntry struct {
+	move pieces.Move
+	// claim is ThreefoldRepetition or FiftyMoveRule if a draw was claimed after the move, and NoTermination otherwise
+	claim Termination
+}
+
+// History returns the moves made so far, in order
+func (g *Game) History() []pieces.Move {
+	moves := make([]pieces.Move, len(g.history))
+	for i, entry := range g.history {
+		moves[i] = entry.move
+	}
+	return moves
+}
+
+// CanUndo returns whether there is a move to take back
+func (g *Game) CanUndo() bool {
+	return len(g.history) > 0
+}
+
+// CanRedo returns whether there is a taken back move to replay, which is not the case once the game is over, such
+// as after a draw has been claimed
+func (g *Game) CanRedo() bool {
+	return !g.IsOver() && len(g.redo) > 0
+}
+
+// Undo takes back the last move, restoring the position exactly as it was before the move was made. Taking back the
+// move before a claimed draw reopens the game, and redoing it restores the claim
+func (g *Game) Undo() error {
+	if !g.CanUndo() {
+		return ErrNothingToUndo
+	}
+	entry := redoEntry{}
+	if g.termination == ThreefoldRepetition || g.termination == FiftyMoveRule {
+		entry.claim = g.termination
+	}
+	entry.move = g.unapplyLast()
+	g.redo = append(g.redo, entry)
+	return nil
+}
+
+// Redo replays the last move taken back by Undo
+func (g *Game) Redo() error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+	if !g.CanRedo() {
+		return ErrNothingToRedo
+	}
+	entry := g.redo[len(g.redo)-1]
+	g.redo = g.redo[:len(g.redo)-1]
+	g.apply(entry.move)
+	if entry.claim != NoTermination {
+		g.termination = entry.claim
+		g.result = Draw
+	}
+	return nil
+}
+
+// unapplyLast takes back the last move in the history, returns the turn to the side that made it and returns the
+// move
+func (g *Game) unapplyLast() pieces.Move {
+	entry := g.history[len(g.history)-1]
+	g.history = g.history[:len(g.history)-1]
+	m := entry.move
+
+	if m.IsCastle() {
+		rook := g.board.RemovePiece(castledRookLocation(m)).(*pieces.Rook)
+		g.board.RemovePiece(m.To)
+		m.Piece.(*pieces.King).UndoCastle(m.From, rook)
+		g.board.PlacePiece(rook)
+	} else {
+		// removing the piece at the destination also removes the piece a pawn was promoted to
+		g.board.RemovePiece(m.To)
+		m.Piece.Unmove(m.From, entry.hadMoved)
+	}
+	g.board.PlacePiece(m.Piece)
+
+	if m.Captured != nil {
+		g.board.PlacePiece(m.Captured)
+	}
+
+	g.position.UnmakeMove(bitboard.MoveOf(m), entry.undo)
+	g.enPassant = entry.enPassant
+	g.halfmoveClock = entry.halfmoveClock
+	g.fullmoveNumber = entry.fullmoveNumber
+	g.result = entry.result
+	g.termination = entry.termination
+	g.turn = g.turn.Opponent()
+	return m
+}
+
+// castlingRookLocation returns the location of the rook before a castling move
+func castlingRookLocation(m pieces.Move) location.Location {
+	if m.Is(pieces.QueensideCastle) {
+		return location.Location{Row: m.From.GetRow(), Col: 0}
+	}
+	return location.Location{Row: m.From.GetRow(), Col: pieces.BOARD_SIZE - 1}
+}
+
+// castledRookLocation returns the location of the rook after a castling move
+func castledRookLocation(m pieces.Move) location.Location {
+	if m.Is(pieces.QueensideCastle) {
+		return location.Location{Row: m.To.GetRow(), Col: setup.QueensideCastleRookColumn}
+	}
+	return location.Location{Row: m.To.GetRow(), Col: setup.StandardCastleRookColumn}
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"chess/pieces"
+	"sort"
+)
+
+// DivideResult is the number of positions reached below one of the legal moves of a game
+type DivideResult struct {
+	Move  pieces.Move
+	Nodes uint64
+}
+
+// Perft returns the number of positions reached by playing every sequence of legal moves of a given length from the
+// current position, using the move generation of the pieces package. The game is left as it was
+func (g *Game) Perft(depth int) uint64 {
+	if depth == 0 {
+		return 1
+	}
+	moves := g.LegalMoves()
+	if depth == 1 {
+		return uint64(len(moves))
+	}
+
+	var nodes uint64
+	for _, m := range moves {
+		g.apply(m)
+		nodes += g.Perft(depth - 1)
+		g.unapplyLast()
+	}
+	return nodes
+}
+
+// Divide returns the perft count below each legal move, ordered by move, which narrows a wrong count down to the
+// moves that cause it
+func (g *Game) Divide(depth int) []DivideResult {
+	var results []DivideResult
+	if depth < 1 {
+		return results
+	}
+	for _, m := range g.LegalMoves() {
+		g.apply(m)
+		results = append(results, DivideResult{Move: m, Nodes: g.Perft(depth - 1)})
+		g.unapplyLast()
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Move.String() < results[j].Move.String()
+	})
+	return results
+}
@@ -0,0 +1,372 @@
+package game
+
+import (
+	"chess/pieces"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// sevenTagRoster are the tags every PGN game record starts with, in the order they are written
+var sevenTagRoster = []string{"Event", "Site", "Date", "Round", "White", "Black", "Result"}
+
+// ErrInvalidPGN is returned, wrapped in a PGNError, when PGN text is malformed
+var ErrInvalidPGN = errors.New("invalid PGN")
+
+// PGNError describes where in PGN text a game could not be read
+type PGNError struct {
+	Line int
+	Col  int
+	Err  error
+}
+
+func (e *PGNError) Error() string {
+	return fmt.Sprintf("pgn:%d:%d: %v", e.Line, e.Col, e.Err)
+}
+
+// Unwrap returns the underlying error so that errors.Is can match against ErrInvalidPGN and the errors returned by
+// MoveSAN
+func (e *PGNError) Unwrap() error {
+	return e.Err
+}
+
+// Record is a game along with the PGN tags describing it
+type Record struct {
+	Tags map[string]string
+	Game *Game
+}
+
+// ReadPGN reads every game in PGN text, replaying the moves of each game to check that they are legal
+func ReadPGN(r io.Reader) ([]*Record, error) {
+	text, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &pgnParser{text: []rune(string(text)), line: 1, col: 1}
+
+	var records []*Record
+	for {
+		p.skipWhitespace()
+		if p.done() {
+			return records, nil
+		}
+		record, err := p.parseGame()
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
+
+// WritePGN writes a game as PGN, with the seven tag roster first followed by any other tags in alphabetical order.
+// Missing roster tags are written as unknown, and the Result tag is taken from the game when it has ended
+func WritePGN(w io.Writer, g *Game, tags map[string]string) error {
+	result := g.Result().String()
+	if !g.IsOver() && tags["Result"] != "" {
+		result = tags["Result"]
+	}
+
+	all := map[string]string{
+		"Event": "?",
+		"Site":  "?",
+		"Date":  "????.??.??",
+		"Round": "?",
+		"White": "?",
+		"Black": "?",
+	}
+	for name, value := range tags {
+		all[name] = value
+	}
+	all["Result"] = result
+	if g.InitialFEN() != StartingFEN {
+		all["SetUp"] = "1"
+		all["FEN"] = g.InitialFEN()
+	}
+
+	var others []string
+	for name := range all {
+		if !isRosterTag(name) {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+
+	var sb strings.Builder
+	for _, name := range append(append([]string{}, sevenTagRoster...), others...) {
+		fmt.Fprintf(&sb, "[%s \"%s\"]\n", name, escapeTag(all[name]))
+	}
+	sb.WriteByte('\n')
+
+	movetext, err := g.movetext()
+	if err != nil {
+		return err
+	}
+	writeWrapped(&sb, append(movetext, result))
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// movetext returns the move numbers and moves of the game in Standard Algebraic Notation
+func (g *Game) movetext() ([]string, error) {
+	// replaying from a clone rather than from the initial FEN also covers games set up with NewGame in positions a
+	// FEN cannot be read back from, such as positions without both kings
+	replay := g.Clone()
+	for replay.CanUndo() {
+		replay.unapplyLast()
+	}
+
+	var tokens []string
+	for i, m := range g.History() {
+		move, err := replay.resolveMove(m)
+		if err != nil {
+			return nil, err
+		}
+		if replay.turn == pieces.WHITE {
+			tokens = append(tokens, strconv.Itoa(replay.fullmoveNumber)+".")
+		} else if i == 0 {
+			tokens = append(tokens, strconv.Itoa(replay.fullmoveNumber)+"...")
+		}
+		tokens = append(tokens, replay.sanOf(move))
+		replay.apply(move)
+	}
+	return tokens, nil
+}
+
+// writeWrapped writes tokens separated by spaces, wrapping lines before they exceed 80 characters
+func writeWrapped(sb *strings.Builder, tokens []string) {
+	lineLength := 0
+	for _, token := range tokens {
+		if lineLength > 0 && lineLength+1+len(token) > 80 {
+			sb.WriteByte('\n')
+			lineLength = 0
+		}
+		if lineLength > 0 {
+			sb.WriteByte(' ')
+			lineLength++
+		}
+		sb.WriteString(token)
+		lineLength += len(token)
+	}
+	sb.WriteByte('\n')
+}
+
+func isRosterTag(name string) bool {
+	for _, roster := range sevenTagRoster {
+		if name == roster {
+			return true
+		}
+	}
+	return false
+}
+
+func escapeTag(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
+// pgnParser reads PGN text one rune at a time, keeping track of the line and column for error messages
+type pgnParser struct {
+	text []rune
+	pos  int
+	line int
+	col  int
+}
+
+func (p *pgnParser) done() bool {
+	return p.pos >= len(p.text)
+}
+
+func (p *pgnParser) peek() rune {
+	return p.text[p.pos]
+}
+
+func (p *pgnParser) next() rune {
+	r := p.text[p.pos]
+	p.pos++
+	if r == '\n' {
+		p.line++
+		p.col = 1
+	} else {
+		p.col++
+	}
+	return r
+}
+
+func (p *pgnParser) errorAt(line, col int, err error) error {
+	return &PGNError{Line: line, Col: col, Err: err}
+}
+
+func (p *pgnParser) errorf(line, col int, format string, args ...interface{}) error {
+	return p.errorAt(line, col, fmt.Errorf("%w: %s", ErrInvalidPGN, fmt.Sprintf(format, args...)))
+}
+
+// skipWhitespace skips whitespace, comments and escaped lines
+func (p *pgnParser) skipWhitespace() {
+	for !p.done() {
+		r := p.peek()
+		switch {
+		case unicode.IsSpace(r):
+			p.next()
+		case r == ';' || (r == '%' && p.col == 1):
+			for !p.done() && p.peek() != '\n' {
+				p.next()
+			}
+		case r == '{':
+			for !p.done() && p.next() != '}' {
+			}
+		default:
+			return
+		}
+	}
+}
+
+// parseGame parses the tags and movetext of a single game
+func (p *pgnParser) parseGame() (*Record, error) {
+	tags := map[string]string{}
+	for !p.done() && p.peek() == '[' {
+		name, value, err := p.parseTag()
+		if err != nil {
+			return nil, err
+		}
+		tags[name] = value
+		p.skipWhitespace()
+	}
+
+	g := NewStandardGame()
+	if fen, ok := tags["FEN"]; ok {
+		var err error
+		g, err = NewGameFromFEN(fen)
+		if err != nil {
+			return nil, p.errorAt(p.line, p.col, err)
+		}
+	}
+
+	for {
+		p.skipWhitespace()
+		if p.done() {
+			return nil, p.errorf(p.line, p.col, "missing game termination marker")
+		}
+		line, col := p.line, p.col
+
+		switch r := p.peek(); {
+		case r == '(':
+			if err := p.skipVariation(); err != nil {
+				return nil, err
+			}
+			continue
+		case r == '$':
+			p.next()
+			p.readToken()
+			continue
+		case r == '[':
+			return nil, p.errorf(line, col, "tag pair in movetext")
+		}
+
+		token := p.readToken()
+		if token == "" {
+			return nil, p.errorf(line, col, "unexpected %q", p.next())
+		}
+		if isGameTermination(token) {
+			if result, ok := tags["Result"]; ok && result != token {
+				return nil, p.errorf(line, col, "termination marker %q does not match Result tag %q", token, result)
+			}
+			tags["Result"] = token
+			return &Record{Tags: tags, Game: g}, nil
+		}
+
+		// strip the move number that may precede the move without a space
+		san := strings.TrimLeft(token, "0123456789")
+		if strings.HasPrefix(san, ".") {
+			san = strings.TrimLeft(san, ".")
+		} else {
+			san = token
+		}
+		if san == "" {
+			continue
+		}
+
+		if err := g.MoveSAN(san); err != nil {
+			return nil, p.errorAt(line, col, err)
+		}
+	}
+}
+
+// parseTag parses a tag pair such as [Event "Casual game"]
+func (p *pgnParser) parseTag() (string, string, error) {
+	line, col := p.line, p.col
+	p.next()
+	p.skipWhitespace()
+
+	name := p.readToken()
+	if name == "" {
+		return "", "", p.errorf(line, col, "missing tag name")
+	}
+	p.skipWhitespace()
+	if p.done() || p.peek() != '"' {
+		return "", "", p.errorf(p.line, p.col, "missing value for tag %q", name)
+	}
+	p.next()
+
+	var value strings.Builder
+	for {
+		if p.done() || p.peek() == '\n' {
+			return "", "", p.errorf(line, col, "unterminated value for tag %q", name)
+		}
+		r := p.next()
+		if r == '"' {
+			break
+		}
+		if r == '\\' && !p.done() {
+			r = p.next()
+		}
+		value.WriteRune(r)
+	}
+
+	p.skipWhitespace()
+	if p.done() || p.peek() != ']' {
+		return "", "", p.errorf(line, col, "unterminated tag %q", name)
+	}
+	p.next()
+	return name, value.String(), nil
+}
+
+// skipVariation skips a recursive annotation variation, including any nested variations
+func (p *pgnParser) skipVariation() error {
+	line, col := p.line, p.col
+	depth := 0
+	for !p.done() {
+		switch p.next() {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		case '{':
+			for !p.done() && p.next() != '}' {
+			}
+		}
+	}
+	return p.errorf(line, col, "unterminated variation")
+}
+
+// readToken reads a run of characters that can make up a move, move number or game termination marker
+func (p *pgnParser) readToken() string {
+	var sb strings.Builder
+	for !p.done() {
+		r := p.peek()
+		if unicode.IsSpace(r) || strings.ContainsRune("[](){};$\"", r) {
+			break
+		}
+		sb.WriteRune(p.next())
+	}
+	return sb.String()
+}
+
+func isGameTermination(token string) bool {
+	return token == "1-0" || token == "0-1" || token == "1/2-1/2" || token == "*"
+}
@@ -0,0 +1,48 @@
+package game
+
+// Result is the outcome of a game
+type Result int32
+
+const (
+	InProgress Result = iota
+	WhiteWins
+	BlackWins
+	Draw
+)
+
+// String returns the result as it is written in game records
+func (r Result) String() string {
+	return [...]string{
+		"*",
+		"1-0",
+		"0-1",
+		"1/2-1/2",
+	}[r]
+}
+
+// Termination is the reason a game ended
+type Termination int32
+
+const (
+	NoTermination Termination = iota
+	Checkmate
+	Stalemate
+	ThreefoldRepetition
+	FivefoldRepetition
+	FiftyMoveRule
+	SeventyFiveMoveRule
+	InsufficientMaterial
+)
+
+func (t Termination) String() string {
+	return [...]string{
+		"None",
+		"Checkmate",
+		"Stalemate",
+		"Threefold repetition",
+		"Fivefold repetition",
+		"Fifty-move rule",
+		"Seventy-five-move rule",
+		"Insufficient material",
+	}[t]
+}
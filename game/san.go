@@ -0,0 +1,182 @@
+package game
+
+import (
+	"chess/pieces"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrInvalidSAN is returned when a move is not written in Standard Algebraic Notation
+	ErrInvalidSAN = errors.New("invalid SAN")
+	// ErrAmbiguousMove is returned when a move written in Standard Algebraic Notation matches more than one legal move
+	ErrAmbiguousMove = errors.New("ambiguous move")
+)
+
+// sanPattern matches a non-castling move in Standard Algebraic Notation, capturing the piece letter, the origin file
+// and rank used for disambiguation, the capture marker, the destination and the promotion piece
+var sanPattern = regexp.MustCompile(`^([KQRBN])?([a-h])?([1-8])?(x)?([a-h][1-8])(?:=?([QRBN]))?$`)
+
+// SAN returns a legal move for the side to move written in Standard Algebraic Notation, such as "Nxe5+", "O-O-O" or
+// "e8=Q". Only the From, To and Promotion fields of the move need to be set
+func (g *Game) SAN(m pieces.Move) (string, error) {
+	move, err := g.resolveMove(m)
+	if err != nil {
+		return "", err
+	}
+	return g.sanOf(move), nil
+}
+
+// ParseSAN returns the legal move for the side to move that is written in Standard Algebraic Notation. Check,
+// checkmate and annotation suffixes are ignored
+func (g *Game) ParseSAN(san string) (pieces.Move, error) {
+	return g.parseSAN(san)
+}
+
+// MoveSAN makes the move written in Standard Algebraic Notation for the side to move
+func (g *Game) MoveSAN(san string) error {
+	m, err := g.parseSAN(san)
+	if err != nil {
+		return err
+	}
+	g.apply(m)
+	g.redo = nil
+	return nil
+}
+
+// sanOf returns a legal move written in Standard Algebraic Notation in the current position
+func (g *Game) sanOf(m pieces.Move) string {
+	var sb strings.Builder
+
+	switch {
+	case m.Is(pieces.KingsideCastle):
+		sb.WriteString("O-O")
+	case m.Is(pieces.QueensideCastle):
+		sb.WriteString("O-O-O")
+	default:
+		letter := pieceLetter(m.Piece)
+		if letter == "" {
+			// pawn captures are identified by the file the pawn came from
+			if m.IsCapture() {
+				sb.WriteByte(m.From.String()[0])
+			}
+		} else {
+			sb.WriteString(letter)
+			sb.WriteString(g.disambiguation(m))
+		}
+		if m.IsCapture() {
+			sb.WriteByte('x')
+		}
+		sb.WriteString(m.To.String())
+		if m.Promotion != pieces.NoPromotion {
+			sb.WriteByte('=')
+			sb.WriteRune(m.Promotion.Kind().Symbol(pieces.WHITE))
+		}
+	}
+
+	// make the move to find out whether it gives check or checkmate
+	g.apply(m)
+	if g.termination == Checkmate {
+		sb.WriteByte('#')
+	} else if g.InCheck() {
+		sb.WriteByte('+')
+	}
+	g.unapplyLast()
+
+	return sb.String()
+}
+
+// disambiguation returns the origin file, rank or square needed to tell a move apart from moves of other pieces of
+// the same kind to the same location
+func (g *Game) disambiguation(m pieces.Move) string {
+	sameFile, sameRank, ambiguous := false, false, false
+	for _, other := range g.LegalMoves() {
+		if other.Piece == m.Piece || !other.To.Equals(m.To) || pieceLetter(other.Piece) != pieceLetter(m.Piece) {
+			continue
+		}
+		ambiguous = true
+		if other.From.GetCol() == m.From.GetCol() {
+			sameFile = true
+		}
+		if other.From.GetRow() == m.From.GetRow() {
+			sameRank = true
+		}
+	}
+
+	from := m.From.String()
+	switch {
+	case !ambiguous:
+		return ""
+	case !sameFile:
+		return from[:1]
+	case !sameRank:
+		return from[1:]
+	default:
+		return from
+	}
+}
+
+// parseSAN returns the legal move written in Standard Algebraic Notation in the current position
+func (g *Game) parseSAN(san string) (pieces.Move, error) {
+	if g.IsOver() {
+		return pieces.Move{}, fmt.Errorf("%q: %w", san, ErrGameOver)
+	}
+	s := strings.TrimSuffix(strings.TrimRight(san, "+#!?"), "e.p.")
+
+	if s == "O-O" || s == "0-0" || s == "O-O-O" || s == "0-0-0" {
+		flag := pieces.KingsideCastle
+		if len(s) == 5 {
+			flag = pieces.QueensideCastle
+		}
+		for _, m := range g.LegalMoves() {
+			if m.Is(flag) {
+				return m, nil
+			}
+		}
+		return pieces.Move{}, fmt.Errorf("%q: %w", san, ErrIllegalMove)
+	}
+
+	groups := sanPattern.FindStringSubmatch(s)
+	if groups == nil {
+		return pieces.Move{}, fmt.Errorf("%q: %w", san, ErrInvalidSAN)
+	}
+	letter, file, rank, to, promotion := groups[1], groups[2], groups[3], groups[5], groups[6]
+
+	var matches []pieces.Move
+	for _, m := range g.LegalMoves() {
+		if pieceLetter(m.Piece) != letter || m.To.String() != to {
+			continue
+		}
+		from := m.From.String()
+		if (file != "" && from[:1] != file) || (rank != "" && from[1:] != rank) {
+			continue
+		}
+		if m.Promotion == pieces.NoPromotion {
+			if promotion != "" {
+				continue
+			}
+		} else if promotion == "" || m.Promotion.Kind().Symbol(pieces.WHITE) != rune(promotion[0]) {
+			continue
+		}
+		matches = append(matches, m)
+	}
+
+	switch len(matches) {
+	case 0:
+		return pieces.Move{}, fmt.Errorf("%q: %w", san, ErrIllegalMove)
+	case 1:
+		return matches[0], nil
+	default:
+		return pieces.Move{}, fmt.Errorf("%q: %w", san, ErrAmbiguousMove)
+	}
+}
+
+// pieceLetter returns the letter identifying a piece in algebraic notation, or "" for a pawn
+func pieceLetter(p pieces.Piece) string {
+	if p.Kind() == pieces.PawnKind {
+		return ""
+	}
+	return string(p.Kind().Symbol(pieces.WHITE))
+}
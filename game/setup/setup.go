@@ -15,3 +15,19 @@ const (
 	StandardCastleColumn  int = 6
 	QueensideCastleColumn int = 2
 )
+
+const (
+	StandardCastleRookColumn  int = 5
+	QueensideCastleRookColumn int = 3
+)
+
+const (
+	QueensideRookColumn int = iota
+	QueensideKnightColumn
+	QueensideBishopColumn
+	QueenColumn
+	KingColumn
+	KingsideBishopColumn
+	KingsideKnightColumn
+	KingsideRookColumn
+)
@@ -0,0 +1,53 @@
+package game
+
+import (
+	"chess/board"
+	"chess/board/location"
+	"chess/game/setup"
+	"chess/pieces"
+)
+
+// StandardPosition returns a board with the pieces of a standard game in their starting locations, along with the
+// pieces themselves
+func StandardPosition() (*board.Board, []pieces.Piece) {
+	var pcs []pieces.Piece
+	pcs = append(pcs, backRank(setup.WhiteFirstRank, pieces.WHITE)...)
+	pcs = append(pcs, pawnRank(setup.WhiteSecondRank, pieces.WHITE)...)
+	pcs = append(pcs, pawnRank(setup.BlackSecondRank, pieces.BLACK)...)
+	pcs = append(pcs, backRank(setup.BlackFirstRank, pieces.BLACK)...)
+
+	b := board.NewBoard()
+	for _, p := range pcs {
+		b.PlacePiece(p)
+	}
+	return b, pcs
+}
+
+// NewStandardGame returns a new game from the standard starting position
+func NewStandardGame() *Game {
+	return NewGame(StandardPosition())
+}
+
+func backRank(row int, c pieces.PieceColor) []pieces.Piece {
+	loc := func(col int) location.Location {
+		return location.Location{Row: row, Col: col}
+	}
+	return []pieces.Piece{
+		pieces.NewRook(loc(setup.QueensideRookColumn), c),
+		pieces.NewKnight(loc(setup.QueensideKnightColumn), c),
+		pieces.NewBishop(loc(setup.QueensideBishopColumn), c),
+		pieces.NewQueen(loc(setup.QueenColumn), c),
+		pieces.NewKing(loc(setup.KingColumn), c),
+		pieces.NewBishop(loc(setup.KingsideBishopColumn), c),
+		pieces.NewKnight(loc(setup.KingsideKnightColumn), c),
+		pieces.NewRook(loc(setup.KingsideRookColumn), c),
+	}
+}
+
+func pawnRank(row int, c pieces.PieceColor) []pieces.Piece {
+	pcs := make([]pieces.Piece, 0, board.DEFAULT_BOARD_SIZE)
+	for col := 0; col < board.DEFAULT_BOARD_SIZE; col++ {
+		pcs = append(pcs, pieces.NewPawn(location.Location{Row: row, Col: col}, c))
+	}
+	return pcs
+}
@@ -0,0 +1,14 @@
+// Package xorshift is a small pseudo random number generator, used instead of math/rand wherever the module needs
+// numbers that are the same on every run, so that they do not depend on the standard library's generator
+package xorshift
+
+// Source generates a fixed sequence of numbers from the value it is seeded with
+type Source uint64
+
+// Next returns the next number in the sequence
+func (x *Source) Next() uint64 {
+	*x ^= *x >> 12
+	*x ^= *x << 25
+	*x ^= *x >> 27
+	return uint64(*x) * 0x2545f4914f6cdd1d
+}
@@ -0,0 +1,127 @@
+package pieces
+
+import (
+	"chess/board/location"
+	"math/bits"
+)
+
+var (
+	allBearings = []bearing{
+		{Row: 0, Col: 1},
+		{Row: 1, Col: 0},
+		{Row: 0, Col: -1},
+		{Row: -1, Col: 0},
+		{Row: 1, Col: 1},
+		{Row: -1, Col: 1},
+		{Row: 1, Col: -1},
+		{Row: -1, Col: -1},
+	}
+
+	knightBearings = []bearing{
+		{Row: -1, Col: 2},
+		{Row: 1, Col: 2},
+		{Row: 2, Col: 1},
+		{Row: 2, Col: -1},
+		{Row: 1, Col: -2},
+		{Row: -1, Col: -2},
+		{Row: -2, Col: -1},
+		{Row: -2, Col: 1},
+	}
+)
+
+// AttackedLocations returns every location attacked by at least one piece of a given color, without duplicates
+func AttackedLocations(attacker PieceColor, pcs []Piece) []location.Location {
+	var attacked []location.Location
+	seen := map[location.Location]bool{}
+	for _, p := range pcs {
+		if p.Color() != attacker {
+			continue
+		}
+		for _, loc := range p.Attacks(pcs) {
+			if !seen[loc] {
+				seen[loc] = true
+				attacked = append(attacked, loc)
+			}
+		}
+	}
+	return attacked
+}
+
+// IsAttacked returns whether any piece of the attacking color attacks a given location
+func IsAttacked(loc location.Location, attacker PieceColor, pcs []Piece) bool {
+	for _, p := range pcs {
+		if p.Color() != attacker {
+			continue
+		}
+		for _, l := range p.Attacks(pcs) {
+			if l.Equals(loc) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// slidingLookup returns the squares a sliding piece on a square attacks given the occupied squares, such as
+// magic.RookAttacks
+type slidingLookup func(s int, occupied uint64) uint64
+
+// slidingAttacks returns the locations a sliding piece attacks from a location, up to and including the first
+// occupied location in each direction, whichever color occupies it. The attacks are looked up in the magic tables
+func slidingAttacks(from location.Location, lookup slidingLookup, pcs []Piece) []location.Location {
+	occupied, _ := occupancy(WHITE, pcs)
+	return locationsOf(lookup(squareIndex(from), occupied))
+}
+
+// slidingMoves returns the locations a sliding piece of a given color can move to from a location, which are the
+// locations it attacks that are not occupied by its own pieces
+func slidingMoves(from location.Location, c PieceColor, lookup slidingLookup, pcs []Piece) []location.Location {
+	occupied, own := occupancy(c, pcs)
+	return locationsOf(lookup(squareIndex(from), occupied) &^ own)
+}
+
+// occupancy returns the squares occupied by any piece, and the squares occupied by pieces of a given color, as sets
+// of square indexes
+func occupancy(c PieceColor, pcs []Piece) (uint64, uint64) {
+	var occupied, own uint64
+	for _, p := range pcs {
+		if !p.Location().IsValid(BOARD_SIZE) {
+			continue
+		}
+		bit := uint64(1) << uint(squareIndex(p.Location()))
+		occupied |= bit
+		if p.Color() == c {
+			own |= bit
+		}
+	}
+	return occupied, own
+}
+
+// squareIndex returns the index of a location counting along each rank from a1 = 0 to h8 = 63, as the magic tables
+// number squares
+func squareIndex(loc location.Location) int {
+	return loc.GetRow()*BOARD_SIZE + loc.GetCol()
+}
+
+// locationsOf returns the locations in a set of square indexes
+func locationsOf(squares uint64) []location.Location {
+	var locs []location.Location
+	for squares != 0 {
+		s := bits.TrailingZeros64(squares)
+		locs = append(locs, location.Location{Row: s / BOARD_SIZE, Col: s % BOARD_SIZE})
+		squares &= squares - 1
+	}
+	return locs
+}
+
+// stepAttacks returns the locations a single step along each bearing from a location
+func stepAttacks(from location.Location, bearings []bearing) []location.Location {
+	var attacks []location.Location
+	for _, b := range bearings {
+		loc := location.Location{Row: from.GetRow() + b.Row, Col: from.GetCol() + b.Col}
+		if loc.IsValid(BOARD_SIZE) {
+			attacks = append(attacks, loc)
+		}
+	}
+	return attacks
+}
@@ -1,6 +1,9 @@
 package pieces
 
-import "chess/board/location"
+import (
+	"chess/board/bitboard/magic"
+	"chess/board/location"
+)
 
 // Bishop represents a bishop chess piece
 type Bishop struct {
@@ -33,50 +36,50 @@ func (b *Bishop) HasMoved() bool {
 	return b.hasMoved
 }
 
+// Kind returns the kind of the piece
+func (b *Bishop) Kind() Kind {
+	return BishopKind
+}
+
+// Symbol returns the FEN symbol of the bishop
+func (b *Bishop) Symbol() rune {
+	return BishopKind.Symbol(b.color)
+}
+
+// UnicodeSymbol returns the Unicode chess symbol of the bishop
+func (b *Bishop) UnicodeSymbol() rune {
+	return BishopKind.UnicodeSymbol(b.color)
+}
+
+// Value returns the nominal material value of the bishop in pawns
+func (b *Bishop) Value() int {
+	return BishopKind.Value()
+}
+
+// Clone returns a copy of the bishop that can be moved without affecting the original
+func (b *Bishop) Clone() Piece {
+	clone := *b
+	return &clone
+}
+
 // Move moves the bishop to a new location and sets hasMoved to true
 func (b *Bishop) Move(newLocation location.Location) {
 	b.loc = newLocation
 	b.hasMoved = true
 }
 
-// ValidMoves returns all of the current possible moves for the bishop
-func (b *Bishop) ValidMoves(pcs []Piece) []location.Location {
-	bearings := []bearing{
-		{Row: 1, Col: 1},
-		{Row: -1, Col: 1},
-		{Row: 1, Col: -1},
-		{Row: -1, Col: -1},
-	}
-
-	currentRow := b.loc.GetRow()
-	currentCol := b.loc.GetCol()
+// Unmove returns the bishop to a previous location and restores whether it had moved, taking back a call to Move
+func (b *Bishop) Unmove(previousLocation location.Location, hadMoved bool) {
+	b.loc = previousLocation
+	b.hasMoved = hadMoved
+}
 
-	var validMoves []location.Location
-	var loc location.Location
-	isBlocked := false
+// Attacks returns all of the locations the bishop attacks, including locations occupied by pieces of its own color
+func (b *Bishop) Attacks(pcs []Piece) []location.Location {
+	return slidingAttacks(b.loc, magic.BishopAttacks, pcs)
+}
 
-	for _, bear := range bearings {
-		loc = location.Location{Row: currentRow, Col: currentCol}
-		isBlocked = false
-		for !isBlocked {
-			loc = location.Location{Row: loc.GetRow() + bear.Row, Col: loc.GetCol() + bear.Col}
-			// check if location is valid
-			if isValidLocation(loc) {
-				// check if location is vacant
-				if !isLocationOccupied(loc, pcs) {
-					validMoves = append(validMoves, loc)
-				} else {
-					// check if location is occupied by opponent
-					if isLocationOccupiedByOpponent(loc, b.color, pcs) {
-						validMoves = append(validMoves, loc)
-					}
-					isBlocked = true
-				}
-			} else {
-				// if location is invalid, piece is blocked by the edge of the board
-				isBlocked = true
-			}
-		}
-	}
-	return validMoves
+// ValidMoves returns all of the current possible moves for the bishop
+func (b *Bishop) ValidMoves(pcs []Piece) []location.Location {
+	return slidingMoves(b.loc, b.color, magic.BishopAttacks, pcs)
 }
@@ -0,0 +1,110 @@
+package pieces
+
+import (
+	"chess/board/location"
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+// ErrInvalidSymbol is returned, wrapped with the symbol, when a character does not stand for a piece
+var ErrInvalidSymbol = errors.New("invalid piece symbol")
+
+// Kind is the kind of a piece, such as a knight or a rook
+type Kind int32
+
+const (
+	PawnKind Kind = iota
+	KnightKind
+	BishopKind
+	RookKind
+	QueenKind
+	KingKind
+)
+
+// Kinds are every kind of piece, from least to most valuable
+var Kinds = []Kind{
+	PawnKind,
+	KnightKind,
+	BishopKind,
+	RookKind,
+	QueenKind,
+	KingKind,
+}
+
+func (k Kind) String() string {
+	return [...]string{
+		"Pawn",
+		"Knight",
+		"Bishop",
+		"Rook",
+		"Queen",
+		"King",
+	}[k]
+}
+
+// Symbol returns the FEN symbol of a piece of this kind, which is uppercase for white pieces and lowercase for black
+// pieces
+func (k Kind) Symbol(c PieceColor) rune {
+	symbol := []rune("PNBRQK")[k]
+	if c == BLACK {
+		return unicode.ToLower(symbol)
+	}
+	return symbol
+}
+
+// UnicodeSymbol returns the Unicode chess symbol of a piece of this kind, such as ♘ for a white knight
+func (k Kind) UnicodeSymbol(c PieceColor) rune {
+	if c == BLACK {
+		return []rune("♟♞♝♜♛♚")[k]
+	}
+	return []rune("♙♘♗♖♕♔")[k]
+}
+
+// Value returns the nominal material value of a piece of this kind in pawns. The king is given no value, as it can
+// never be traded
+func (k Kind) Value() int {
+	return [...]int{1, 3, 3, 5, 9, 0}[k]
+}
+
+// ParseSymbol returns the kind and color of the piece a FEN or Unicode chess symbol stands for
+func ParseSymbol(symbol rune) (Kind, PieceColor, error) {
+	for _, k := range Kinds {
+		for _, c := range []PieceColor{WHITE, BLACK} {
+			if symbol == k.Symbol(c) || symbol == k.UnicodeSymbol(c) {
+				return k, c, nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("%w: %q", ErrInvalidSymbol, symbol)
+}
+
+// NewPiece returns a new piece of a given kind and color at a given location
+func NewPiece(k Kind, l location.Location, c PieceColor) Piece {
+	switch k {
+	case PawnKind:
+		return NewPawn(l, c)
+	case KnightKind:
+		return NewKnight(l, c)
+	case BishopKind:
+		return NewBishop(l, c)
+	case RookKind:
+		return NewRook(l, c)
+	case QueenKind:
+		return NewQueen(l, c)
+	case KingKind:
+		return NewKing(l, c)
+	default:
+		panic(fmt.Sprintf("pieces: unknown kind %d", int32(k)))
+	}
+}
+
+// NewPieceFromSymbol returns a new piece at a given location from a FEN or Unicode chess symbol, such as 'N' for a
+// white knight or '♜' for a black rook
+func NewPieceFromSymbol(symbol rune, l location.Location) (Piece, error) {
+	k, c, err := ParseSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return NewPiece(k, l, c), nil
+}
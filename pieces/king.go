@@ -36,12 +36,49 @@ func (k *King) HasMoved() bool {
 	return k.hasMoved
 }
 
+// Kind returns the kind of the piece
+func (k *King) Kind() Kind {
+	return KingKind
+}
+
+// Symbol returns the FEN symbol of the king
+func (k *King) Symbol() rune {
+	return KingKind.Symbol(k.color)
+}
+
+// UnicodeSymbol returns the Unicode chess symbol of the king
+func (k *King) UnicodeSymbol() rune {
+	return KingKind.UnicodeSymbol(k.color)
+}
+
+// Value returns the nominal material value of the king in pawns
+func (k *King) Value() int {
+	return KingKind.Value()
+}
+
+// Clone returns a copy of the king that can be moved without affecting the original
+func (k *King) Clone() Piece {
+	clone := *k
+	return &clone
+}
+
 // Move moves the king to a new location and sets hasMoved to true
 func (k *King) Move(newLocation location.Location) {
 	k.loc = newLocation
 	k.hasMoved = true
 }
 
+// Unmove returns the king to a previous location and restores whether it had moved, taking back a call to Move
+func (k *King) Unmove(previousLocation location.Location, hadMoved bool) {
+	k.loc = previousLocation
+	k.hasMoved = hadMoved
+}
+
+// Attacks returns all of the locations the king attacks, whether or not they are occupied
+func (k *King) Attacks(pcs []Piece) []location.Location {
+	return stepAttacks(k.loc, allBearings)
+}
+
 // ValidMoves returns all of the current possible moves the king can make
 func (k *King) ValidMoves(pcs []Piece) []location.Location {
 	bearings := []bearing{
@@ -63,7 +100,7 @@ func (k *King) ValidMoves(pcs []Piece) []location.Location {
 
 	for _, b := range bearings {
 		loc = location.Location{Row: currentRow + b.Row, Col: currentCol + b.Col}
-		if isValidLocation(loc) {
+		if loc.IsValid(BOARD_SIZE) {
 			// location must either be vacant or occupied by opponent
 			if !isLocationOccupied(loc, pcs) || isLocationOccupiedByOpponent(loc, k.color, pcs) {
 				// cannot move into check
@@ -76,98 +113,132 @@ func (k *King) ValidMoves(pcs []Piece) []location.Location {
 
 	// check if can standard castle
 	if k.canCastle(false, pcs) {
-		if k.color == BLACK {
-			loc = location.Location{Row: setup.BlackFirstRank, Col: setup.StandardCastleColumn}
-		} else {
-			loc = location.Location{Row: setup.WhiteFirstRank, Col: setup.StandardCastleColumn}
-		}
-		validMoves = append(validMoves, loc)
+		validMoves = append(validMoves, location.Location{Row: k.firstRank(), Col: setup.StandardCastleColumn})
 	}
 	// check if can queenside castle
 	if k.canCastle(true, pcs) {
-		if k.color == BLACK {
-			loc = location.Location{Row: setup.BlackFirstRank, Col: setup.QueensideCastleColumn}
-		} else {
-			loc = location.Location{Row: setup.WhiteFirstRank, Col: setup.QueensideCastleColumn}
-		}
-		validMoves = append(validMoves, loc)
+		validMoves = append(validMoves, location.Location{Row: k.firstRank(), Col: setup.QueensideCastleColumn})
 	}
 	return validMoves
 }
 
 func (k *King) canCastle(queenside bool, pcs []Piece) bool {
+	if !k.HasCastlingRight(queenside, pcs) {
+		return false
+	}
+
+	rook := k.castlingRook(queenside, pcs)
+	locationsToCheck := k.getLocationsToCheckForCastle(queenside)
+	if !k.checkLocationsForCastle(rook.Location(), locationsToCheck, pcs) {
+		return false
+	}
+
+	// king cannot castle out of, through or into check
+	destination := setup.StandardCastleColumn
+	if queenside {
+		destination = setup.QueensideCastleColumn
+	}
+	step := 1
+	if destination < k.loc.GetCol() {
+		step = -1
+	}
+	for col := k.loc.GetCol(); ; col += step {
+		if k.LocationInCheck(location.Location{Row: k.loc.GetRow(), Col: col}, pcs) {
+			return false
+		}
+		if col == destination {
+			return true
+		}
+	}
+}
+
+// HasCastlingRight returns whether the king keeps the right to castle towards a given side, which requires that
+// neither the king nor the rook it would castle with has moved, and that both stand on their starting squares. It
+// does not consider whether castling is currently possible
+func (k *King) HasCastlingRight(queenside bool, pcs []Piece) bool {
 	// if king has moved, cannot castle
 	if k.hasMoved {
 		return false
 	}
 
-	// initialize expected rook location
-	var rookStartingLoc location.Location
-	if k.color == BLACK {
-		rookStartingLoc.Row = setup.BlackFirstRank
-	} else {
-		rookStartingLoc.Row = setup.WhiteFirstRank
+	// king can only castle from its starting square, which a king placed elsewhere on the first rank is not on
+	if k.loc.GetRow() != k.firstRank() || k.loc.GetCol() != setup.KingColumn {
+		return false
 	}
 
+	// if there is no unmoved rook where the rook should be, cannot castle
+	rook := k.castlingRook(queenside, pcs)
+	return rook != nil && !rook.HasMoved()
+}
+
+// IsCastleMove returns whether moving the king to a given location would castle
+func (k *King) IsCastleMove(to location.Location) bool {
+	colDiff := to.GetCol() - k.loc.GetCol()
+	return !k.hasMoved && k.loc.GetCol() == setup.KingColumn && to.GetRow() == k.loc.GetRow() &&
+		(colDiff == 2 || colDiff == -2)
+}
+
+// Castle moves the king to a castling location and moves the rook it castles with to the other side of the king.
+// It returns the rook that was moved
+func (k *King) Castle(to location.Location, pcs []Piece) *Rook {
+	queenside := to.GetCol() < k.loc.GetCol()
+	rook := k.castlingRook(queenside, pcs)
+
+	rookDestination := location.Location{Row: to.GetRow(), Col: setup.StandardCastleRookColumn}
+	if queenside {
+		rookDestination.Col = setup.QueensideCastleRookColumn
+	}
+
+	k.Move(to)
+	rook.Move(rookDestination)
+	return rook
+}
+
+// UndoCastle returns the king to the location it castled from and the rook it castled with to its corner, as if
+// neither piece had moved
+func (k *King) UndoCastle(from location.Location, rook *Rook) {
+	rookCol := BOARD_SIZE - 1
+	if rook.loc.GetCol() == setup.QueensideCastleRookColumn {
+		rookCol = 0
+	}
+	rook.loc = location.Location{Row: rook.loc.GetRow(), Col: rookCol}
+	rook.hasMoved = false
+
+	k.loc = from
+	k.hasMoved = false
+}
+
+// castlingRook returns the rook of the king's color in the corner the king would castle towards, or nil if there is none
+func (k *King) castlingRook(queenside bool, pcs []Piece) *Rook {
+	rookStartingLoc := location.Location{Row: k.firstRank(), Col: BOARD_SIZE - 1}
 	if queenside {
 		rookStartingLoc.Col = 0
-	} else {
-		rookStartingLoc.Col = BOARD_SIZE - 1
 	}
 
-	pieceFound := false
-	// check if rook has moved
 	for _, p := range pcs {
-		if p.Location().Equals(rookStartingLoc) {
-			pieceFound = true
-			// if the piece at the starting location has moved, then cannot castle
-			if p.HasMoved() {
-				return false
-			}
+		if r, isRook := p.(*Rook); isRook && r.color == k.color && r.loc.Equals(rookStartingLoc) {
+			return r
 		}
 	}
+	return nil
+}
 
-	// if there is no piece where the rook should be, cannot castle
-	if !pieceFound {
-		return false
+// firstRank returns the row the king's side starts on
+func (k *King) firstRank() int {
+	if k.color == BLACK {
+		return setup.BlackFirstRank
 	}
-
-	locationsToCheck := k.getLocationsToCheckForCastle(queenside)
-	return k.checkLocationsForCastle(rookStartingLoc, locationsToCheck, pcs)
+	return setup.WhiteFirstRank
 }
 
 // LocationInCheck returns whether or not a given location would be in check if the king were placed there
 func (k *King) LocationInCheck(loc location.Location, pcs []Piece) bool {
-	var pieceValidMoves []location.Location
-	for _, p := range pcs {
-		if p.Color() != k.color {
-			pieceValidMoves = p.ValidMoves(pcs)
-			// check if location is in the valid moves of the opponent piece
-			for _, l := range pieceValidMoves {
-				if loc.Equals(l) {
-					return true
-				}
-			}
-		}
-	}
-	return false
+	return IsAttacked(loc, k.color.Opponent(), pcs)
 }
 
 // InCheck returns whether or not the king is currently in check
 func (k *King) InCheck(pcs []Piece) bool {
-	var pieceValidMoves []location.Location
-	for _, p := range pcs {
-		if p.Color() != k.color {
-			pieceValidMoves = p.ValidMoves(pcs)
-			// check if location is in the valid moves of the opponent piece
-			for _, l := range pieceValidMoves {
-				if k.loc.Equals(l) {
-					return true
-				}
-			}
-		}
-	}
-	return false
+	return k.LocationInCheck(k.loc, pcs)
 }
 
 func (k *King) getLocationsToCheckForCastle(queenside bool) []location.Location {
@@ -211,12 +282,6 @@ func (k *King) checkLocationsForCastle(rookLocation location.Location, locations
 				return false
 			}
 		}
-		if !(l.Equals(rookLocation)) {
-			// if location is in check, cannot castle since king cannot castle through check
-			if k.LocationInCheck(l, pcs) {
-				return false
-			}
-		}
 	}
 	return true
 }
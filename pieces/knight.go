@@ -33,12 +33,49 @@ func (k *Knight) HasMoved() bool {
 	return k.hasMoved
 }
 
+// Kind returns the kind of the piece
+func (k *Knight) Kind() Kind {
+	return KnightKind
+}
+
+// Symbol returns the FEN symbol of the knight
+func (k *Knight) Symbol() rune {
+	return KnightKind.Symbol(k.color)
+}
+
+// UnicodeSymbol returns the Unicode chess symbol of the knight
+func (k *Knight) UnicodeSymbol() rune {
+	return KnightKind.UnicodeSymbol(k.color)
+}
+
+// Value returns the nominal material value of the knight in pawns
+func (k *Knight) Value() int {
+	return KnightKind.Value()
+}
+
+// Clone returns a copy of the knight that can be moved without affecting the original
+func (k *Knight) Clone() Piece {
+	clone := *k
+	return &clone
+}
+
 // Move moves the knight to a new location and sets hasMoved to true
 func (k *Knight) Move(newLocation location.Location) {
 	k.loc = newLocation
 	k.hasMoved = true
 }
 
+// Unmove returns the knight to a previous location and restores whether it had moved, taking back a call to Move
+func (k *Knight) Unmove(previousLocation location.Location, hadMoved bool) {
+	k.loc = previousLocation
+	k.hasMoved = hadMoved
+}
+
+// Attacks returns all of the locations the knight attacks, whether or not they are occupied
+func (k *Knight) Attacks(pcs []Piece) []location.Location {
+	return stepAttacks(k.loc, knightBearings)
+}
+
 // ValidMoves returns a slice of all of the knight's current possible moves
 func (k *Knight) ValidMoves(pcs []Piece) []location.Location {
 	bearings := []bearing{
@@ -64,7 +101,7 @@ func (k *Knight) ValidMoves(pcs []Piece) []location.Location {
 			Col: currentCol + b.Col,
 		}
 		// check if valid location
-		if isValidLocation(loc) {
+		if loc.IsValid(BOARD_SIZE) {
 			// check if location is vacant or occupied by opponent
 			if !isLocationOccupied(loc, pcs) || isLocationOccupiedByOpponent(loc, k.Color(), pcs) {
 				validMoves = append(validMoves, loc)
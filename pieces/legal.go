@@ -0,0 +1,108 @@
+package pieces
+
+import "chess/board/location"
+
+// displacedPiece stands in for a piece that has been moved to a new location while a hypothetical move is evaluated
+type displacedPiece struct {
+	Piece
+	loc location.Location
+}
+
+// Location returns the location the piece has been displaced to
+func (d displacedPiece) Location() location.Location {
+	return d.loc
+}
+
+// LegalMoves returns all of the moves in a piece's ValidMoves that do not leave its own king in check.
+// enPassant is the location a pawn passed over with a double push on the previous move, or nil if there is none
+func LegalMoves(p Piece, pcs []Piece, enPassant *location.Location) []location.Location {
+	var legalMoves []location.Location
+	for _, loc := range p.ValidMoves(pcs) {
+		if !leavesKingInCheck(p, loc, pieceAt(loc, pcs), pcs) {
+			legalMoves = append(legalMoves, loc)
+		}
+	}
+
+	// check if the piece is a pawn that can capture en passant
+	if pawn, isPawn := p.(*Pawn); isPawn && enPassant != nil && pawn.CanCaptureEnPassant(*enPassant) {
+		captured := pawn.EnPassantCapture(*enPassant, pcs)
+		if captured != nil && !leavesKingInCheck(p, *enPassant, captured, pcs) {
+			legalMoves = append(legalMoves, *enPassant)
+		}
+	}
+	return legalMoves
+}
+
+// pieceAt returns the piece at a given location, or nil if the location is vacant
+func pieceAt(loc location.Location, pcs []Piece) Piece {
+	for _, p := range pcs {
+		if p.Location().Equals(loc) {
+			return p
+		}
+	}
+	return nil
+}
+
+// leavesKingInCheck returns whether moving a piece to a location and capturing another piece would leave the king
+// of the same color in check. captured may be nil if the move is not a capture
+func leavesKingInCheck(p Piece, to location.Location, captured Piece, pcs []Piece) bool {
+	var kingLoc location.Location
+	if p.Kind() == KingKind {
+		kingLoc = to
+	} else {
+		king := findKing(p.Color(), pcs)
+		// without a king there is nothing to leave in check
+		if king == nil {
+			return false
+		}
+		kingLoc = king.Location()
+	}
+
+	// build the piece configuration as it would be after the move
+	after := make([]Piece, 0, len(pcs)+1)
+	for _, other := range pcs {
+		if other == p || other == captured {
+			continue
+		}
+		after = append(after, other)
+	}
+	after = append(after, displacedPiece{Piece: p, loc: to})
+
+	return IsAttacked(kingLoc, p.Color().Opponent(), after)
+}
+
+// findKing returns the king of a given color, or nil if there is none
+func findKing(c PieceColor, pcs []Piece) *King {
+	for _, p := range pcs {
+		if k, isKing := p.(*King); isKing && k.Color() == c {
+			return k
+		}
+	}
+	return nil
+}
+
+// HasLegalMoves returns whether any piece of a given color has at least one legal move
+func HasLegalMoves(c PieceColor, pcs []Piece, enPassant *location.Location) bool {
+	for _, p := range pcs {
+		if p.Color() == c && len(LegalMoves(p, pcs, enPassant)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// InCheck returns whether the king of a given color is in check
+func InCheck(c PieceColor, pcs []Piece) bool {
+	king := findKing(c, pcs)
+	return king != nil && king.InCheck(pcs)
+}
+
+// IsCheckmate returns whether the king of a given color is in check and its side has no legal moves
+func IsCheckmate(c PieceColor, pcs []Piece, enPassant *location.Location) bool {
+	return InCheck(c, pcs) && !HasLegalMoves(c, pcs, enPassant)
+}
+
+// IsStalemate returns whether a given color is not in check but has no legal moves
+func IsStalemate(c PieceColor, pcs []Piece, enPassant *location.Location) bool {
+	return !InCheck(c, pcs) && !HasLegalMoves(c, pcs, enPassant)
+}
@@ -0,0 +1,174 @@
+package pieces
+
+import (
+	"chess/board/location"
+	"chess/game/setup"
+	"fmt"
+)
+
+// Promotion is the kind of piece a pawn is promoted to when it reaches the last rank
+type Promotion int32
+
+const (
+	NoPromotion Promotion = iota
+	PromoteToQueen
+	PromoteToRook
+	PromoteToBishop
+	PromoteToKnight
+)
+
+// Promotions are the pieces a pawn can be promoted to
+var Promotions = []Promotion{
+	PromoteToQueen,
+	PromoteToRook,
+	PromoteToBishop,
+	PromoteToKnight,
+}
+
+func (pr Promotion) String() string {
+	return [...]string{
+		"None",
+		"Queen",
+		"Rook",
+		"Bishop",
+		"Knight",
+	}[pr]
+}
+
+// Kind returns the kind of piece a pawn is promoted to. It must not be called for NoPromotion
+func (pr Promotion) Kind() Kind {
+	return [...]Kind{
+		PromoteToQueen:  QueenKind,
+		PromoteToRook:   RookKind,
+		PromoteToBishop: BishopKind,
+		PromoteToKnight: KnightKind,
+	}[pr]
+}
+
+// NewPiece returns a new piece of the promoted kind at a given location, or nil if there is no promotion
+func (pr Promotion) NewPiece(l location.Location, c PieceColor) Piece {
+	if pr == NoPromotion {
+		return nil
+	}
+	return NewPiece(pr.Kind(), l, c)
+}
+
+// MoveFlag marks a move as special in some way
+type MoveFlag uint8
+
+const (
+	DoublePush MoveFlag = 1 << iota
+	EnPassant
+	KingsideCastle
+	QueensideCastle
+)
+
+// Move represents a piece moving from one location to another, along with everything needed to describe, apply or
+// take back the move without consulting the rest of the pieces
+type Move struct {
+	From      location.Location
+	To        location.Location
+	Piece     Piece
+	Captured  Piece
+	Promotion Promotion
+	Flags     MoveFlag
+}
+
+// Is returns whether the move has a given flag set
+func (m Move) Is(flag MoveFlag) bool {
+	return m.Flags&flag != 0
+}
+
+// IsCapture returns whether the move captures a piece
+func (m Move) IsCapture() bool {
+	return m.Captured != nil
+}
+
+// IsCastle returns whether the move is a kingside or queenside castle
+func (m Move) IsCastle() bool {
+	return m.Is(KingsideCastle) || m.Is(QueensideCastle)
+}
+
+// Matches returns whether the move goes between the same locations and promotes to the same piece as another move
+func (m Move) Matches(other Move) bool {
+	return m.From.Equals(other.From) && m.To.Equals(other.To) && m.Promotion == other.Promotion
+}
+
+func (m Move) String() string {
+	s := fmt.Sprintf("%v-%v", m.From, m.To)
+	if m.Promotion != NoPromotion {
+		s += "=" + m.Promotion.String()
+	}
+	return s
+}
+
+// IsPromotionMove returns whether a piece moving to a given location must be promoted
+func IsPromotionMove(p Piece, to location.Location) bool {
+	if p.Kind() != PawnKind {
+		return false
+	}
+	if p.Color() == WHITE {
+		return to.GetRow() == setup.BlackFirstRank
+	}
+	return to.GetRow() == setup.WhiteFirstRank
+}
+
+// GenerateMoves returns all of the legal moves for a given color. A pawn reaching the last rank yields one move for
+// each piece it can be promoted to
+func GenerateMoves(c PieceColor, pcs []Piece, enPassant *location.Location) []Move {
+	var moves []Move
+	for _, p := range pcs {
+		if p.Color() == c {
+			moves = append(moves, PieceMoves(p, pcs, enPassant)...)
+		}
+	}
+	return moves
+}
+
+// PieceMoves returns all of the legal moves for a single piece
+func PieceMoves(p Piece, pcs []Piece, enPassant *location.Location) []Move {
+	var moves []Move
+	for _, to := range LegalMoves(p, pcs, enPassant) {
+		m := newMove(p, to, pcs, enPassant)
+		if IsPromotionMove(p, to) {
+			for _, pr := range Promotions {
+				m.Promotion = pr
+				moves = append(moves, m)
+			}
+		} else {
+			moves = append(moves, m)
+		}
+	}
+	return moves
+}
+
+// newMove returns a move of a piece to a location with its captured piece and flags filled in
+func newMove(p Piece, to location.Location, pcs []Piece, enPassant *location.Location) Move {
+	m := Move{
+		From:     p.Location(),
+		To:       to,
+		Piece:    p,
+		Captured: pieceAt(to, pcs),
+	}
+
+	switch piece := p.(type) {
+	case *Pawn:
+		rowDiff := to.GetRow() - m.From.GetRow()
+		if rowDiff == 2 || rowDiff == -2 {
+			m.Flags |= DoublePush
+		}
+		if m.Captured == nil && enPassant != nil && to.Equals(*enPassant) {
+			m.Flags |= EnPassant
+			m.Captured = piece.EnPassantCapture(to, pcs)
+		}
+	case *King:
+		if piece.IsCastleMove(to) {
+			if to.GetCol() > m.From.GetCol() {
+				m.Flags |= KingsideCastle
+			} else {
+				m.Flags |= QueensideCastle
+			}
+		}
+	}
+	return m
+}
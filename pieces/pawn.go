@@ -33,11 +33,45 @@ func (p *Pawn) HasMoved() bool {
 	return p.hasMoved
 }
 
+// Kind returns the kind of the piece
+func (p *Pawn) Kind() Kind {
+	return PawnKind
+}
+
+// Symbol returns the FEN symbol of the pawn
+func (p *Pawn) Symbol() rune {
+	return PawnKind.Symbol(p.color)
+}
+
+// UnicodeSymbol returns the Unicode chess symbol of the pawn
+func (p *Pawn) UnicodeSymbol() rune {
+	return PawnKind.UnicodeSymbol(p.color)
+}
+
+// Value returns the nominal material value of the pawn in pawns
+func (p *Pawn) Value() int {
+	return PawnKind.Value()
+}
+
+// Clone returns a copy of the pawn that can be moved without affecting the original
+func (p *Pawn) Clone() Piece {
+	clone := *p
+	return &clone
+}
+
 // Location returns the location of the pawn on the board
 func (p *Pawn) Location() location.Location {
 	return p.loc
 }
 
+// Attacks returns the locations diagonally in front of the pawn, which it attacks whether or not they are occupied
+func (p *Pawn) Attacks(pcs []Piece) []location.Location {
+	return stepAttacks(p.loc, []bearing{
+		{Row: p.direction(), Col: 1},
+		{Row: p.direction(), Col: -1},
+	})
+}
+
 // ValidMoves returns a slice of all moves the pawn can make given a piece configuration
 func (p *Pawn) ValidMoves(pcs []Piece) []location.Location {
 	currentRow := p.loc.GetRow()
@@ -45,28 +79,23 @@ func (p *Pawn) ValidMoves(pcs []Piece) []location.Location {
 
 	var validMoves []location.Location
 
-	var movementDirection int
-	if p.color == BLACK {
-		movementDirection = BLACK_DIRECTION
-	} else {
-		movementDirection = WHITE_DIRECTION
-	}
+	movementDirection := p.direction()
 
 	var loc location.Location
 	// check locations diagonally in front of pawn to see if a capture can be made
 	// check first diagonal
 	loc = location.Location{Row: currentRow + movementDirection, Col: currentCol + 1}
-	if isValidLocation(loc) && isLocationOccupiedByOpponent(loc, p.Color(), pcs) {
+	if loc.IsValid(BOARD_SIZE) && isLocationOccupiedByOpponent(loc, p.Color(), pcs) {
 		validMoves = append(validMoves, loc)
 	}
 	// check second diagonal
 	loc = location.Location{Row: currentRow + movementDirection, Col: currentCol - 1}
-	if isValidLocation(loc) && isLocationOccupiedByOpponent(loc, p.Color(), pcs) {
+	if loc.IsValid(BOARD_SIZE) && isLocationOccupiedByOpponent(loc, p.Color(), pcs) {
 		validMoves = append(validMoves, loc)
 	}
 	// check location directly in front of pawn
 	loc = location.Location{Row: currentRow + movementDirection, Col: currentCol}
-	if isValidLocation(loc) && !isLocationOccupied(loc, pcs) {
+	if loc.IsValid(BOARD_SIZE) && !isLocationOccupied(loc, pcs) {
 		validMoves = append(validMoves, loc)
 	} else {
 		return validMoves
@@ -74,14 +103,47 @@ func (p *Pawn) ValidMoves(pcs []Piece) []location.Location {
 
 	// if piece hasn't moved, check 2 spaces ahead of this piece
 	loc = location.Location{Row: currentRow + 2*movementDirection, Col: currentCol}
-	if !p.hasMoved && isValidLocation(loc) && !isLocationOccupied(loc, pcs) {
+	if !p.hasMoved && loc.IsValid(BOARD_SIZE) && !isLocationOccupied(loc, pcs) {
 		validMoves = append(validMoves, loc)
 	}
 	return validMoves
 }
 
+// CanCaptureEnPassant returns whether the pawn is positioned to capture en passant onto a given target location,
+// which is the location an opponent pawn passed over with a double push on the previous move
+func (p *Pawn) CanCaptureEnPassant(target location.Location) bool {
+	colDiff := target.GetCol() - p.loc.GetCol()
+	return target.GetRow() == p.loc.GetRow()+p.direction() && (colDiff == 1 || colDiff == -1)
+}
+
+// EnPassantCapture returns the opponent pawn that would be captured by moving en passant onto a given target location,
+// or nil if there is no such pawn
+func (p *Pawn) EnPassantCapture(target location.Location, pcs []Piece) Piece {
+	loc := location.Location{Row: p.loc.GetRow(), Col: target.GetCol()}
+	for _, other := range pcs {
+		if other.Kind() == PawnKind && other.Color() != p.color && other.Location().Equals(loc) {
+			return other
+		}
+	}
+	return nil
+}
+
+// direction returns the row direction the pawn moves in
+func (p *Pawn) direction() int {
+	if p.color == BLACK {
+		return BLACK_DIRECTION
+	}
+	return WHITE_DIRECTION
+}
+
 // Move sets the location of the pawn to a new location and sets hasMoved member to true
 func (p *Pawn) Move(newLocation location.Location) {
 	p.loc = newLocation
 	p.hasMoved = true
 }
+
+// Unmove returns the pawn to a previous location and restores whether it had moved, taking back a call to Move
+func (p *Pawn) Unmove(previousLocation location.Location, hadMoved bool) {
+	p.loc = previousLocation
+	p.hasMoved = hadMoved
+}
@@ -4,11 +4,6 @@ import "chess/board/location"
 
 const BOARD_SIZE int = 8
 
-func isValidLocation(l location.Location) bool {
-	return l.GetRow() >= 0 && l.GetRow() < BOARD_SIZE &&
-		l.GetCol() >= 0 && l.GetCol() < BOARD_SIZE
-}
-
 func isLocationOccupied(loc location.Location, pcs []Piece) bool {
 	for _, p := range pcs {
 		if loc.Equals(p.Location()) {
@@ -42,16 +37,40 @@ func (pc PieceColor) String() string {
 	}[pc]
 }
 
+// Opponent returns the color of the opposing side
+func (pc PieceColor) Opponent() PieceColor {
+	if pc == BLACK {
+		return WHITE
+	}
+	return BLACK
+}
+
 // Piece represents a chess piece
 type Piece interface {
 	Color() PieceColor
 	Location() location.Location
 	HasMoved() bool
+	Kind() Kind
+	Symbol() rune
+	UnicodeSymbol() rune
+	Value() int
+	Clone() Piece
 	ValidMoves([]Piece) []location.Location
+	Attacks([]Piece) []location.Location
 	Move(location.Location)
+	Unmove(location.Location, bool)
+}
+
+// ClonePieces returns copies of a set of pieces that can be moved without affecting the originals
+func ClonePieces(pcs []Piece) []Piece {
+	clones := make([]Piece, len(pcs))
+	for i, p := range pcs {
+		clones[i] = p.Clone()
+	}
+	return clones
 }
 
 type bearing struct {
 	Row int
 	Col int
-}
\ No newline at end of file
+}
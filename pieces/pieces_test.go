@@ -2,6 +2,7 @@ package pieces
 
 import (
 	"chess/board/location"
+	"errors"
 	"testing"
 )
 
@@ -158,6 +159,29 @@ func TestCaptureWhitePawn(t *testing.T) {
 	evaluate(validMoves, expectedMoves, t)
 }
 
+func TestEnPassantPinned(t *testing.T) {
+	p := NewPawn(location.Location{Row: 3, Col: 4}, WHITE)
+	p.Move(location.Location{Row: 4, Col: 4})
+	target := location.Location{Row: 5, Col: 3}
+
+	// capturing en passant would remove both pawns from the fifth rank and expose the king
+	pcs := []Piece{
+		NewKing(location.Location{Row: 4, Col: 7}, WHITE),
+		NewPawn(location.Location{Row: 4, Col: 3}, BLACK),
+		NewRook(location.Location{Row: 4, Col: 0}, BLACK),
+		NewKing(location.Location{Row: 7, Col: 0}, BLACK),
+		p,
+	}
+
+	validMoves := LegalMoves(p, pcs, &target)
+
+	expectedMoves := []location.Location{
+		{Row: 5, Col: 4},
+	}
+
+	evaluate(validMoves, expectedMoves, t)
+}
+
 func TestKnight(t *testing.T) {
 	k := NewKnight(location.Location{Row: 2, Col: 6}, WHITE)
 
@@ -347,6 +371,106 @@ func TestCastleThroughCheck(t *testing.T) {
 		{Row: 1, Col: 3},
 		{Row: 1, Col: 4},
 		{Row: 0, Col: 5},
+		// the rook may pass over an attacked location when castling queenside, only the king's path must be safe
+		{Row: 0, Col: 2},
+	}
+
+	evaluate(validMoves, expectedMoves, t)
+}
+
+func TestPinnedKnight(t *testing.T) {
+	k := NewKnight(location.Location{Row: 1, Col: 4}, WHITE)
+
+	pcs := []Piece{
+		NewKing(location.Location{Row: 0, Col: 4}, WHITE),
+		NewRook(location.Location{Row: 7, Col: 4}, BLACK),
+		NewKing(location.Location{Row: 7, Col: 0}, BLACK),
+		k,
+	}
+
+	validMoves := LegalMoves(k, pcs, nil)
+
+	var expectedMoves []location.Location
+
+	evaluate(validMoves, expectedMoves, t)
+}
+
+func TestDiscoveredCheck(t *testing.T) {
+	b := NewBishop(location.Location{Row: 2, Col: 4}, WHITE)
+
+	pcs := []Piece{
+		NewKing(location.Location{Row: 0, Col: 4}, WHITE),
+		NewQueen(location.Location{Row: 6, Col: 4}, BLACK),
+		NewKing(location.Location{Row: 7, Col: 7}, BLACK),
+		b,
+	}
+
+	validMoves := LegalMoves(b, pcs, nil)
+
+	var expectedMoves []location.Location
+
+	evaluate(validMoves, expectedMoves, t)
+}
+
+func TestMovesWhileInCheck(t *testing.T) {
+	r := NewRook(location.Location{Row: 3, Col: 0}, WHITE)
+
+	pcs := []Piece{
+		NewKing(location.Location{Row: 0, Col: 4}, WHITE),
+		NewRook(location.Location{Row: 5, Col: 4}, BLACK),
+		NewKing(location.Location{Row: 7, Col: 7}, BLACK),
+		r,
+	}
+
+	validMoves := LegalMoves(r, pcs, nil)
+
+	// the rook can only block the check
+	expectedMoves := []location.Location{
+		{Row: 3, Col: 4},
+	}
+
+	evaluate(validMoves, expectedMoves, t)
+}
+
+func TestKingCannotCaptureProtectedPiece(t *testing.T) {
+	k := NewKing(location.Location{Row: 0, Col: 0}, WHITE)
+
+	pcs := []Piece{
+		NewPawn(location.Location{Row: 1, Col: 1}, BLACK),
+		NewPawn(location.Location{Row: 2, Col: 2}, BLACK),
+		NewKing(location.Location{Row: 7, Col: 7}, BLACK),
+		k,
+	}
+
+	validMoves := LegalMoves(k, pcs, nil)
+
+	expectedMoves := []location.Location{
+		{Row: 0, Col: 1},
+		{Row: 1, Col: 0},
+	}
+
+	evaluate(validMoves, expectedMoves, t)
+}
+
+func TestKingMovesAwayAlongCheckingLine(t *testing.T) {
+	k := NewKing(location.Location{Row: 3, Col: 3}, WHITE)
+	k.Move(location.Location{Row: 3, Col: 4})
+
+	pcs := []Piece{
+		NewRook(location.Location{Row: 3, Col: 0}, BLACK),
+		NewKing(location.Location{Row: 7, Col: 7}, BLACK),
+		k,
+	}
+
+	validMoves := LegalMoves(k, pcs, nil)
+
+	expectedMoves := []location.Location{
+		{Row: 2, Col: 3},
+		{Row: 2, Col: 4},
+		{Row: 2, Col: 5},
+		{Row: 4, Col: 3},
+		{Row: 4, Col: 4},
+		{Row: 4, Col: 5},
 	}
 
 	evaluate(validMoves, expectedMoves, t)
@@ -374,3 +498,261 @@ func contains(moves []location.Location, loc location.Location) bool {
 	}
 	return false
 }
+
+func TestGeneratePromotionMoves(t *testing.T) {
+	pcs := []Piece{
+		NewPawn(location.Location{Row: 6, Col: 0}, WHITE),
+		NewRook(location.Location{Row: 7, Col: 1}, BLACK),
+	}
+
+	moves := GenerateMoves(WHITE, pcs, nil)
+
+	// a push and a capture, each with four promotion choices
+	if len(moves) != 8 {
+		t.Fatalf("expected 8 moves, got %d", len(moves))
+	}
+	for _, m := range moves {
+		if m.Promotion == NoPromotion {
+			t.Errorf("expected every move to promote: %v", m)
+		}
+	}
+}
+
+func TestCastleMovesRook(t *testing.T) {
+	k := NewKing(location.Location{Row: 0, Col: 4}, WHITE)
+	r := NewRook(location.Location{Row: 0, Col: 0}, WHITE)
+
+	pcs := []Piece{k, r}
+
+	from := k.Location()
+	to := location.Location{Row: 0, Col: 2}
+	if !k.IsCastleMove(to) {
+		t.Fatalf("expected %v to be a castle move", to)
+	}
+
+	rook := k.Castle(to, pcs)
+	if rook != r {
+		t.Fatalf("expected queenside rook to castle")
+	}
+	if !k.Location().Equals(to) || !r.Location().Equals(location.Location{Row: 0, Col: 3}) {
+		t.Errorf("unexpected locations after castle: king %v, rook %v", k.Location(), r.Location())
+	}
+	if !k.HasMoved() || !r.HasMoved() {
+		t.Errorf("expected king and rook to have moved")
+	}
+
+	k.UndoCastle(from, rook)
+	if !k.Location().Equals(from) || !r.Location().Equals(location.Location{Row: 0, Col: 0}) {
+		t.Errorf("unexpected locations after undo: king %v, rook %v", k.Location(), r.Location())
+	}
+	if k.HasMoved() || r.HasMoved() {
+		t.Errorf("expected king and rook to be unmoved after undo")
+	}
+}
+
+func TestCastleRequiresOwnRook(t *testing.T) {
+	k := NewKing(location.Location{Row: 0, Col: 4}, WHITE)
+
+	pcs := []Piece{
+		NewRook(location.Location{Row: 0, Col: 7}, BLACK),
+		NewKnight(location.Location{Row: 0, Col: 0}, WHITE),
+		k,
+	}
+
+	validMoves := k.ValidMoves(pcs)
+
+	expectedMoves := []location.Location{
+		{Row: 0, Col: 3},
+		{Row: 1, Col: 3},
+		{Row: 1, Col: 4},
+		{Row: 1, Col: 5},
+	}
+
+	evaluate(validMoves, expectedMoves, t)
+}
+
+func TestCastleRequiresKingOnStartingSquare(t *testing.T) {
+	k := NewKing(location.Location{Row: 0, Col: 3}, WHITE)
+
+	pcs := []Piece{
+		NewRook(location.Location{Row: 0, Col: 7}, WHITE),
+		k,
+	}
+
+	if k.HasCastlingRight(false, pcs) {
+		t.Errorf("expected a king away from its starting square to have no castling right")
+	}
+
+	validMoves := k.ValidMoves(pcs)
+
+	expectedMoves := []location.Location{
+		{Row: 0, Col: 2},
+		{Row: 0, Col: 4},
+		{Row: 1, Col: 2},
+		{Row: 1, Col: 3},
+		{Row: 1, Col: 4},
+	}
+
+	evaluate(validMoves, expectedMoves, t)
+}
+
+func TestPieceMovesFlags(t *testing.T) {
+	k := NewKing(location.Location{Row: 0, Col: 4}, WHITE)
+	r := NewRook(location.Location{Row: 0, Col: 7}, WHITE)
+	p := NewPawn(location.Location{Row: 1, Col: 0}, WHITE)
+	ep := NewPawn(location.Location{Row: 3, Col: 4}, WHITE)
+	ep.Move(location.Location{Row: 4, Col: 4})
+	victim := NewPawn(location.Location{Row: 4, Col: 5}, BLACK)
+	target := location.Location{Row: 5, Col: 5}
+
+	pcs := []Piece{k, r, p, ep, victim, NewKing(location.Location{Row: 7, Col: 0}, BLACK)}
+
+	foundCastle := false
+	for _, m := range PieceMoves(k, pcs, &target) {
+		if m.To.Equals(location.Location{Row: 0, Col: 6}) {
+			foundCastle = true
+			if !m.Is(KingsideCastle) {
+				t.Errorf("expected %v to be flagged as a kingside castle", m)
+			}
+		}
+	}
+	if !foundCastle {
+		t.Errorf("expected a kingside castle onto %v", location.Location{Row: 0, Col: 6})
+	}
+	for _, m := range PieceMoves(p, pcs, &target) {
+		if m.To.Equals(location.Location{Row: 3, Col: 0}) != m.Is(DoublePush) {
+			t.Errorf("unexpected double push flag on %v", m)
+		}
+	}
+
+	foundEnPassant := false
+	for _, m := range PieceMoves(ep, pcs, &target) {
+		if m.To.Equals(target) {
+			foundEnPassant = true
+			if !m.Is(EnPassant) || m.Captured != victim {
+				t.Errorf("expected %v to capture %v en passant", m, victim.Location())
+			}
+		}
+		if m.Piece != ep || !m.From.Equals(ep.Location()) {
+			t.Errorf("expected move to carry its piece and origin: %v", m)
+		}
+	}
+	if !foundEnPassant {
+		t.Errorf("expected an en passant capture onto %v", target)
+	}
+}
+
+func TestPawnAttacks(t *testing.T) {
+	p := NewPawn(location.Location{Row: 1, Col: 0}, WHITE)
+
+	pcs := []Piece{
+		p,
+		NewKnight(location.Location{Row: 2, Col: 0}, BLACK),
+	}
+
+	// the pawn attacks the empty diagonal but not the location in front of it
+	attacks := p.Attacks(pcs)
+	expectedAttacks := []location.Location{
+		{Row: 2, Col: 1},
+	}
+
+	evaluate(attacks, expectedAttacks, t)
+}
+
+func TestAttackedLocations(t *testing.T) {
+	pcs := []Piece{
+		NewRook(location.Location{Row: 0, Col: 0}, WHITE),
+		NewPawn(location.Location{Row: 1, Col: 0}, WHITE),
+		NewKnight(location.Location{Row: 0, Col: 1}, WHITE),
+	}
+
+	attacked := AttackedLocations(WHITE, pcs)
+
+	// the rook defends both neighbouring pieces, the pawn attacks b3 and the knight attacks a3, c3 and d2
+	expectedAttacks := []location.Location{
+		{Row: 1, Col: 0},
+		{Row: 0, Col: 1},
+		{Row: 2, Col: 1},
+		{Row: 2, Col: 0},
+		{Row: 2, Col: 2},
+		{Row: 1, Col: 3},
+	}
+
+	evaluate(attacked, expectedAttacks, t)
+
+	if !IsAttacked(location.Location{Row: 2, Col: 1}, WHITE, pcs) {
+		t.Errorf("expected b3 to be attacked by the pawn")
+	}
+	if IsAttacked(location.Location{Row: 2, Col: 1}, BLACK, pcs) {
+		t.Errorf("expected black to attack nothing")
+	}
+}
+
+func TestOpposingKings(t *testing.T) {
+	k := NewKing(location.Location{Row: 3, Col: 3}, WHITE)
+	k.Move(location.Location{Row: 3, Col: 3})
+
+	pcs := []Piece{
+		NewKing(location.Location{Row: 5, Col: 3}, BLACK),
+		k,
+	}
+
+	validMoves := LegalMoves(k, pcs, nil)
+
+	expectedMoves := []location.Location{
+		{Row: 3, Col: 2},
+		{Row: 3, Col: 4},
+		{Row: 2, Col: 2},
+		{Row: 2, Col: 3},
+		{Row: 2, Col: 4},
+	}
+
+	evaluate(validMoves, expectedMoves, t)
+}
+
+func TestKindSymbolsAndValues(t *testing.T) {
+	loc := location.Location{Row: 3, Col: 3}
+	tests := []struct {
+		piece   Piece
+		kind    Kind
+		symbol  rune
+		unicode rune
+		value   int
+	}{
+		{NewPawn(loc, WHITE), PawnKind, 'P', '♙', 1},
+		{NewKnight(loc, BLACK), KnightKind, 'n', '♞', 3},
+		{NewBishop(loc, WHITE), BishopKind, 'B', '♗', 3},
+		{NewRook(loc, BLACK), RookKind, 'r', '♜', 5},
+		{NewQueen(loc, WHITE), QueenKind, 'Q', '♕', 9},
+		{NewKing(loc, BLACK), KingKind, 'k', '♚', 0},
+	}
+	for _, test := range tests {
+		p := test.piece
+		if p.Kind() != test.kind || p.Symbol() != test.symbol || p.UnicodeSymbol() != test.unicode || p.Value() != test.value {
+			t.Errorf("expected %v %c %c %d, got %v %c %c %d", test.kind, test.symbol, test.unicode, test.value,
+				p.Kind(), p.Symbol(), p.UnicodeSymbol(), p.Value())
+		}
+
+		for _, symbol := range []rune{test.symbol, test.unicode} {
+			built, err := NewPieceFromSymbol(symbol, loc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if built.Kind() != p.Kind() || built.Color() != p.Color() || !built.Location().Equals(loc) {
+				t.Errorf("%c: expected %v %v at %v, got %v %v at %v", symbol, p.Color(), p.Kind(), loc,
+					built.Color(), built.Kind(), built.Location())
+			}
+		}
+	}
+
+	if _, err := NewPieceFromSymbol('x', loc); !errors.Is(err, ErrInvalidSymbol) {
+		t.Errorf("expected ErrInvalidSymbol, got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected NewPiece to panic on an unknown kind")
+		}
+	}()
+	NewPiece(KingKind+1, loc, WHITE)
+}
@@ -1,6 +1,9 @@
 package pieces
 
-import "chess/board/location"
+import (
+	"chess/board/bitboard/magic"
+	"chess/board/location"
+)
 
 // Queen represents a queen chess piece
 type Queen struct {
@@ -33,54 +36,50 @@ func (q *Queen) HasMoved() bool {
 	return q.hasMoved
 }
 
+// Kind returns the kind of the piece
+func (q *Queen) Kind() Kind {
+	return QueenKind
+}
+
+// Symbol returns the FEN symbol of the queen
+func (q *Queen) Symbol() rune {
+	return QueenKind.Symbol(q.color)
+}
+
+// UnicodeSymbol returns the Unicode chess symbol of the queen
+func (q *Queen) UnicodeSymbol() rune {
+	return QueenKind.UnicodeSymbol(q.color)
+}
+
+// Value returns the nominal material value of the queen in pawns
+func (q *Queen) Value() int {
+	return QueenKind.Value()
+}
+
+// Clone returns a copy of the queen that can be moved without affecting the original
+func (q *Queen) Clone() Piece {
+	clone := *q
+	return &clone
+}
+
 // Move moves the queen to a new location and sets hasMoved to true
 func (q *Queen) Move(newLocation location.Location) {
 	q.loc = newLocation
 	q.hasMoved = true
 }
 
-// ValidMoves returns all of the possible moves that the queen can currently make
-func (q *Queen) ValidMoves(pcs []Piece) []location.Location {
-	bearings := []bearing{
-		{Row: 0, Col: 1},
-		{Row: 1, Col: 1},
-		{Row: 1, Col: 0},
-		{Row: -1, Col: 1},
-		{Row: 0, Col: -1},
-		{Row: -1, Col: -1},
-		{Row: -1, Col: 0},
-		{Row: 1, Col: -1},
-	}
-
-	currentRow := q.loc.GetRow()
-	currentCol := q.loc.GetCol()
+// Unmove returns the queen to a previous location and restores whether it had moved, taking back a call to Move
+func (q *Queen) Unmove(previousLocation location.Location, hadMoved bool) {
+	q.loc = previousLocation
+	q.hasMoved = hadMoved
+}
 
-	var validMoves []location.Location
-	var loc location.Location
-	isBlocked := false
+// Attacks returns all of the locations the queen attacks, including locations occupied by pieces of its own color
+func (q *Queen) Attacks(pcs []Piece) []location.Location {
+	return slidingAttacks(q.loc, magic.QueenAttacks, pcs)
+}
 
-	for _, b := range bearings {
-		isBlocked = false
-		loc = location.Location{Row: currentRow, Col: currentCol}
-		for !isBlocked {
-			loc = location.Location{Row: loc.GetRow() + b.Row, Col: loc.GetCol() + b.Col}
-			// check if location is valid
-			if isValidLocation(loc) {
-				// check if space is vacant
-				if !isLocationOccupied(loc, pcs) {
-					validMoves = append(validMoves, loc)
-				} else {
-					// check if location is occupied by opponent
-					if isLocationOccupiedByOpponent(loc, q.color, pcs) {
-						validMoves = append(validMoves, loc)
-					}
-					isBlocked = true
-				}
-			} else {
-				// if invalid location, piece is blocked by the edge of the board
-				isBlocked = true
-			}
-		}
-	}
-	return validMoves
+// ValidMoves returns all of the possible moves that the queen can currently make
+func (q *Queen) ValidMoves(pcs []Piece) []location.Location {
+	return slidingMoves(q.loc, q.color, magic.QueenAttacks, pcs)
 }
@@ -1,6 +1,9 @@
 package pieces
 
-import "chess/board/location"
+import (
+	"chess/board/bitboard/magic"
+	"chess/board/location"
+)
 
 // Rook represents a rook chess piece
 type Rook struct {
@@ -33,50 +36,50 @@ func (r *Rook) HasMoved() bool {
 	return r.hasMoved
 }
 
+// Kind returns the kind of the piece
+func (r *Rook) Kind() Kind {
+	return RookKind
+}
+
+// Symbol returns the FEN symbol of the rook
+func (r *Rook) Symbol() rune {
+	return RookKind.Symbol(r.color)
+}
+
+// UnicodeSymbol returns the Unicode chess symbol of the rook
+func (r *Rook) UnicodeSymbol() rune {
+	return RookKind.UnicodeSymbol(r.color)
+}
+
+// Value returns the nominal material value of the rook in pawns
+func (r *Rook) Value() int {
+	return RookKind.Value()
+}
+
+// Clone returns a copy of the rook that can be moved without affecting the original
+func (r *Rook) Clone() Piece {
+	clone := *r
+	return &clone
+}
+
 // Move moves the rook to a new location and sets hasMoved to true
 func (r *Rook) Move(newLocation location.Location) {
 	r.loc = newLocation
 	r.hasMoved = true
 }
 
-// ValidMoves returns all of the locations the rook can currently move to
-func (r *Rook) ValidMoves(pcs []Piece) []location.Location {
-	bearings := []bearing{
-		{Row: 0, Col: 1},
-		{Row: 1, Col: 0},
-		{Row: 0, Col: -1},
-		{Row: -1, Col: 0},
-	}
-
-	currentRow := r.loc.GetRow()
-	currentCol := r.loc.GetCol()
+// Unmove returns the rook to a previous location and restores whether it had moved, taking back a call to Move
+func (r *Rook) Unmove(previousLocation location.Location, hadMoved bool) {
+	r.loc = previousLocation
+	r.hasMoved = hadMoved
+}
 
-	var validMoves []location.Location
-	var loc location.Location
-	isBlocked := false
+// Attacks returns all of the locations the rook attacks, including locations occupied by pieces of its own color
+func (r *Rook) Attacks(pcs []Piece) []location.Location {
+	return slidingAttacks(r.loc, magic.RookAttacks, pcs)
+}
 
-	for _, b := range bearings {
-		isBlocked = false
-		loc = location.Location{Row: currentRow, Col: currentCol}
-		for !isBlocked {
-			loc = location.Location{Row: loc.GetRow() + b.Row, Col: loc.GetCol() + b.Col}
-			// check if location is valid
-			if isValidLocation(loc) {
-				// check if location is vacant
-				if !isLocationOccupied(loc, pcs) {
-					validMoves = append(validMoves, loc)
-				} else {
-					// check if location is occupied by opponent
-					if isLocationOccupiedByOpponent(loc, r.color, pcs) {
-						validMoves = append(validMoves, loc)
-					}
-					isBlocked = true
-				}
-			} else {
-				// if the location is invalid, piece is blocked by the edge of the board
-				isBlocked = true
-			}
-		}
-	}
-	return validMoves
+// ValidMoves returns all of the locations the rook can currently move to
+func (r *Rook) ValidMoves(pcs []Piece) []location.Location {
+	return slidingMoves(r.loc, r.color, magic.RookAttacks, pcs)
 }
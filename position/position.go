@@ -0,0 +1,166 @@
+package position
+
+import (
+	"chess/board/bitboard"
+	"chess/board/location"
+	"chess/game"
+	"chess/pieces"
+	"fmt"
+)
+
+// Move is a move between two locations, along with the piece a pawn is promoted to when it reaches the last rank
+type Move struct {
+	From      location.Location
+	To        location.Location
+	Promotion pieces.Promotion
+}
+
+func (m Move) String() string {
+	s := fmt.Sprintf("%v-%v", m.From, m.To)
+	if m.Promotion != pieces.NoPromotion {
+		s += "=" + m.Promotion.String()
+	}
+	return s
+}
+
+// Position is an immutable chess position. A Position is a small value holding no pointers, so it is cheap to copy
+// and safe to share between goroutines. Applying a move returns a new Position and leaves the original unchanged.
+// Positions can be compared with == and used as map keys, and are equal when their FEN strings are equal.
+//
+// The placement is stored as bitboards, but moves are generated from the pieces package, so a Position plays by the
+// same rules as a Game
+type Position struct {
+	board          bitboard.Position
+	halfmoveClock  int
+	fullmoveNumber int
+}
+
+// Standard returns the standard starting position
+func Standard() Position {
+	p, err := FromFEN(game.StartingFEN)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// FromFEN returns the position described by a FEN string
+func FromFEN(fen string) (Position, error) {
+	g, err := game.NewGameFromFEN(fen)
+	if err != nil {
+		return Position{}, err
+	}
+	return FromGame(g), nil
+}
+
+// FromGame returns the current position of a game
+func FromGame(g *game.Game) Position {
+	var enPassant *location.Location
+	if target, ok := g.EnPassantTarget(); ok {
+		enPassant = &target
+	}
+	return Position{
+		board:          *bitboard.FromPieces(g.Pieces(), g.Turn(), enPassant),
+		halfmoveClock:  g.HalfmoveClock(),
+		fullmoveNumber: g.FullmoveNumber(),
+	}
+}
+
+// Turn returns the color to move
+func (p Position) Turn() pieces.PieceColor {
+	return p.board.Turn()
+}
+
+// PieceAt returns the kind and color of the piece at a given location, and whether there is one. There is never a
+// piece at a location off the board
+func (p Position) PieceAt(loc location.Location) (pieces.Kind, pieces.PieceColor, bool) {
+	if !loc.IsValid(pieces.BOARD_SIZE) {
+		return 0, 0, false
+	}
+	return p.board.PieceAt(bitboard.SquareOf(loc))
+}
+
+// HalfmoveClock returns the number of moves made since the last capture or pawn move
+func (p Position) HalfmoveClock() int {
+	return p.halfmoveClock
+}
+
+// FullmoveNumber returns the number of the current move, which starts at 1 and increases after each black move
+func (p Position) FullmoveNumber() int {
+	return p.fullmoveNumber
+}
+
+// Hash returns the Zobrist hash of the position, which, unlike ==, ignores the halfmove clock and fullmove number
+func (p Position) Hash() uint64 {
+	return p.board.Hash()
+}
+
+// InCheck returns whether the side to move is in check
+func (p Position) InCheck() bool {
+	return pieces.InCheck(p.Turn(), p.board.Pieces())
+}
+
+// IsCheckmate returns whether the side to move is in check and has no legal moves
+func (p Position) IsCheckmate() bool {
+	return pieces.IsCheckmate(p.Turn(), p.board.Pieces(), p.enPassant())
+}
+
+// IsStalemate returns whether the side to move is not in check but has no legal moves
+func (p Position) IsStalemate() bool {
+	return pieces.IsStalemate(p.Turn(), p.board.Pieces(), p.enPassant())
+}
+
+// LegalMoves returns all of the legal moves for the side to move. A pawn reaching the last rank yields one move for
+// each piece it can be promoted to
+func (p Position) LegalMoves() []Move {
+	var moves []Move
+	for _, m := range pieces.GenerateMoves(p.Turn(), p.board.Pieces(), p.enPassant()) {
+		moves = append(moves, Move{From: m.From, To: m.To, Promotion: m.Promotion})
+	}
+	return moves
+}
+
+// Apply returns the position after a legal move for the side to move. The move is checked against the rules of the
+// pieces package, the same rules a Game plays by, and rejected with the same errors: game.ErrPromotionRequired when a
+// pawn reaches the last rank without a piece to promote to, and game.ErrIllegalMove otherwise
+func (p Position) Apply(m Move) (Position, error) {
+	requested := pieces.Move{From: m.From, To: m.To, Promotion: m.Promotion}
+	for _, legal := range pieces.GenerateMoves(p.Turn(), p.board.Pieces(), p.enPassant()) {
+		if !legal.Matches(requested) {
+			if legal.From.Equals(m.From) && legal.To.Equals(m.To) && m.Promotion == pieces.NoPromotion {
+				return p, fmt.Errorf("%v: %w", m, game.ErrPromotionRequired)
+			}
+			continue
+		}
+
+		// captures and pawn moves reset the halfmove clock
+		if legal.IsCapture() || legal.Piece.Kind() == pieces.PawnKind {
+			p.halfmoveClock = 0
+		} else {
+			p.halfmoveClock++
+		}
+		if p.Turn() == pieces.BLACK {
+			p.fullmoveNumber++
+		}
+
+		// p is a copy, so making the move on it leaves the caller's position unchanged
+		p.board.MakeMove(bitboard.MoveOf(legal))
+		return p, nil
+	}
+	return p, fmt.Errorf("%v: %w", m, game.ErrIllegalMove)
+}
+
+// FEN returns the FEN string describing the position
+func (p Position) FEN() string {
+	return game.FormatFEN(p.board.Pieces(), p.Turn(), p.enPassant(), p.halfmoveClock, p.fullmoveNumber)
+}
+
+// enPassant returns the location a pawn may capture onto en passant, or nil if there is none
+func (p Position) enPassant() *location.Location {
+	target := p.board.EnPassantTarget()
+	if target == bitboard.NoSquare {
+		return nil
+	}
+	loc := target.Location()
+	return &loc
+}
@@ -0,0 +1,236 @@
+package position
+
+import (
+	"chess/board"
+	"chess/board/location"
+	"chess/game"
+	"chess/pieces"
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestApplyMatchesGame(t *testing.T) {
+	g := game.NewStandardGame()
+	p := Standard()
+	if p.FEN() != game.StartingFEN {
+		t.Fatalf("expected %q, got %q", game.StartingFEN, p.FEN())
+	}
+
+	// covers a capture, en passant, both kinds of castling and a promotion
+	sans := []string{"e4", "d5", "e5", "f5", "exf6", "Nc6", "Nf3", "Bg4", "Be2", "Qd7", "O-O", "O-O-O", "fxg7", "Kb8",
+		"gxh8=N"}
+	var history []Position
+	for _, san := range sans {
+		m, err := g.ParseSAN(san)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", san, err)
+		}
+		if err := g.MoveSAN(san); err != nil {
+			t.Fatalf("%s: unexpected error: %v", san, err)
+		}
+
+		history = append(history, p)
+		next, err := p.Apply(Move{From: m.From, To: m.To, Promotion: m.Promotion})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", san, err)
+		}
+		if next.FEN() != g.FEN() {
+			t.Fatalf("%s: expected %q, got %q", san, g.FEN(), next.FEN())
+		}
+		if next.Hash() != g.Hash() {
+			t.Errorf("%s: expected hash %x, got %x", san, g.Hash(), next.Hash())
+		}
+		p = next
+	}
+
+	// applying moves never changes earlier positions
+	if history[0] != Standard() {
+		t.Errorf("expected the first position to be unchanged, got %q", history[0].FEN())
+	}
+	if history[len(history)-1] == p {
+		t.Errorf("expected the positions before and after a move to differ")
+	}
+}
+
+func TestApplyMatchesGameInUnusualPositions(t *testing.T) {
+	// a king that has not moved but stands off its starting square cannot castle
+	displacedKing := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 3}, pieces.WHITE),
+		pieces.NewRook(location.Location{Row: 0, Col: 7}, pieces.WHITE),
+		pieces.NewKing(location.Location{Row: 7, Col: 4}, pieces.BLACK),
+	}
+	// a rook that has moved back to its corner cannot castle either
+	returnedRook := pieces.NewRook(location.Location{Row: 1, Col: 7}, pieces.WHITE)
+	returnedRook.Move(location.Location{Row: 0, Col: 7})
+	movedRook := []pieces.Piece{
+		pieces.NewKing(location.Location{Row: 0, Col: 4}, pieces.WHITE),
+		pieces.NewRook(location.Location{Row: 0, Col: 0}, pieces.WHITE),
+		returnedRook,
+		pieces.NewKing(location.Location{Row: 7, Col: 4}, pieces.BLACK),
+		pieces.NewRook(location.Location{Row: 7, Col: 7}, pieces.BLACK),
+	}
+
+	games := []*game.Game{
+		game.NewGame(board.NewBoard(), displacedKing),
+		game.NewGame(board.NewBoard(), movedRook),
+	}
+	for _, fen := range []string{
+		"4k3/8/8/8/8/8/8/3K3R w - - 0 1",
+		"r3k2r/8/8/8/8/8/8/R3K2R w Kq - 0 1",
+		"r3k2r/8/8/8/8/8/8/R3K2R b Qk - 3 20",
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+	} {
+		g, err := game.NewGameFromFEN(fen)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		games = append(games, g)
+	}
+
+	for _, g := range games {
+		p := FromGame(g)
+		if p.FEN() != g.FEN() {
+			t.Errorf("expected %q, got %q", g.FEN(), p.FEN())
+		}
+		for _, m := range g.LegalMoves() {
+			played := g.Clone()
+			if err := played.MakeMove(m); err != nil {
+				t.Fatalf("%s: unexpected error: %v", g.FEN(), err)
+			}
+			next, err := p.Apply(Move{From: m.From, To: m.To, Promotion: m.Promotion})
+			if err != nil {
+				t.Fatalf("%s: %v: unexpected error: %v", g.FEN(), m, err)
+			}
+			if next.FEN() != played.FEN() || next.Hash() != played.Hash() {
+				t.Errorf("%s: %v: expected %q, got %q", g.FEN(), m, played.FEN(), next.FEN())
+			}
+		}
+		if p != FromGame(g) {
+			t.Errorf("%s: expected applying moves to leave the position unchanged", g.FEN())
+		}
+	}
+}
+
+func TestLegalMovesMatchGame(t *testing.T) {
+	fens := []string{
+		game.StartingFEN,
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8",
+		"4k3/8/8/2KpP3/8/8/8/8 w - d6 0 1",
+	}
+	for _, fen := range fens {
+		g, err := game.NewGameFromFEN(fen)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p, err := FromFEN(fen)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if p != FromGame(g) || p.FEN() != fen {
+			t.Errorf("expected the position read from FEN to match the game at %q, got %q", fen, p.FEN())
+		}
+
+		var expected, actual []string
+		for _, m := range g.LegalMoves() {
+			expected = append(expected, m.String())
+		}
+		for _, m := range p.LegalMoves() {
+			actual = append(actual, m.String())
+		}
+		sort.Strings(expected)
+		sort.Strings(actual)
+		if len(expected) != len(actual) {
+			t.Fatalf("%s: expected moves %v, got %v", fen, expected, actual)
+		}
+		for i := range expected {
+			if expected[i] != actual[i] {
+				t.Fatalf("%s: expected moves %v, got %v", fen, expected, actual)
+			}
+		}
+	}
+}
+
+func TestPositionsAreComparable(t *testing.T) {
+	play := func(moves ...Move) Position {
+		p := Standard()
+		for _, m := range moves {
+			var err error
+			if p, err = p.Apply(m); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		return p
+	}
+	g1f3 := Move{From: location.Location{Row: 0, Col: 6}, To: location.Location{Row: 2, Col: 5}}
+	g8f6 := Move{From: location.Location{Row: 7, Col: 6}, To: location.Location{Row: 5, Col: 5}}
+	b1c3 := Move{From: location.Location{Row: 0, Col: 1}, To: location.Location{Row: 2, Col: 2}}
+	b8c6 := Move{From: location.Location{Row: 7, Col: 1}, To: location.Location{Row: 5, Col: 2}}
+
+	a := play(g1f3, g8f6, b1c3, b8c6)
+	b := play(b1c3, b8c6, g1f3, g8f6)
+	if a != b {
+		t.Errorf("expected transposed positions to be equal, got %q and %q", a.FEN(), b.FEN())
+	}
+
+	seen := map[Position]int{}
+	seen[a]++
+	seen[b]++
+	seen[Standard()]++
+	if seen[a] != 2 || len(seen) != 2 {
+		t.Errorf("expected equal positions to share a map key, got %v", seen)
+	}
+}
+
+func TestApplyErrorsAndResults(t *testing.T) {
+	p := Standard()
+	illegal := Move{From: location.Location{Row: 0, Col: 0}, To: location.Location{Row: 3, Col: 0}}
+	next, err := p.Apply(illegal)
+	if !errors.Is(err, game.ErrIllegalMove) {
+		t.Errorf("expected ErrIllegalMove, got %v", err)
+	}
+	if next != p {
+		t.Errorf("expected a rejected move to return the position unchanged")
+	}
+	if _, _, ok := p.PieceAt(location.Location{Row: 0, Col: 9}); ok {
+		t.Errorf("expected no piece off the board")
+	}
+
+	// a promotion needs a piece to promote to
+	p, err = FromFEN("4k3/P7/8/8/8/8/8/4K3 w - - 0 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	push := Move{From: location.Location{Row: 6, Col: 0}, To: location.Location{Row: 7, Col: 0}}
+	if _, err := p.Apply(push); !errors.Is(err, game.ErrPromotionRequired) {
+		t.Errorf("expected ErrPromotionRequired without a promotion, got %v", err)
+	}
+	push.Promotion = pieces.PromoteToQueen
+	promoted, err := p.Apply(push)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	kind, c, ok := promoted.PieceAt(location.Location{Row: 7, Col: 0})
+	if !ok || kind != pieces.QueenKind || c != pieces.WHITE {
+		t.Errorf("expected a white queen on a8, got %v %v %v", c, kind, ok)
+	}
+	if !promoted.InCheck() || promoted.IsCheckmate() {
+		t.Errorf("expected black to be in check but not checkmated")
+	}
+
+	mate, err := FromFEN("rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !mate.IsCheckmate() || mate.IsStalemate() {
+		t.Errorf("expected checkmate")
+	}
+	stalemate, err := FromFEN("k7/2Q5/1K6/8/8/8/8/8 b - - 0 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !stalemate.IsStalemate() || stalemate.IsCheckmate() {
+		t.Errorf("expected stalemate")
+	}
+}